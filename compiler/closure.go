@@ -0,0 +1,191 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// closureName is the name of anonymous functions
+const closureName = `fn`
+
+// closure contains information about the anonymous function which is being compiled
+type closure struct {
+	Block    *core.CmdBlock
+	Env      map[string]int // the names of the captured variables and their indexes
+	Captures []core.Capture
+	CurType  *core.TypeObject
+}
+
+func coClosure(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if len(lp.Tokens) == cmpl.pos+1 || lp.Tokens[cmpl.pos+1].Type != tkLPar {
+		return cmpl.Error(ErrValue)
+	}
+	owner := cmpl.curOwner()
+	goExpPush(cmpl, closureName)
+	newFunc(cmpl, closureName)
+	block := &cmpl.latestFunc().Block
+	block.Parent = owner
+	cmpl.closures = append(cmpl.closures, &closure{
+		Block:   block,
+		Env:     make(map[string]int),
+		CurType: cmpl.curType,
+	})
+	return nil
+}
+
+func coClosureResult(cmpl *compiler) error {
+	obj, err := getType(cmpl)
+	if err != nil {
+		return err
+	}
+	cmpl.latestFunc().Block.Result = obj.(*core.TypeObject)
+	return coClosureStart(cmpl)
+}
+
+func coClosureStart(cmpl *compiler) error {
+	block := &cmpl.latestFunc().Block
+	if block.Variadic {
+		return cmpl.ErrorPos(int(block.TokenID), ErrFnVariadic)
+	}
+	block.ParCount = len(block.Vars)
+	return nil
+}
+
+func coClosureBack(cmpl *compiler) error {
+	funcObj := cmpl.latestFunc()
+	if funcObj.Block.Result != nil {
+		children := funcObj.Block.Children
+		if len(children) == 0 || children[len(children)-1].GetType() != core.CtStack ||
+			children[len(children)-1].(*core.CmdBlock).ID != core.StackReturn {
+			return cmpl.Error(ErrMustReturn)
+		}
+	}
+	fn := cmpl.closures[len(cmpl.closures)-1]
+	cmpl.closures = cmpl.closures[:len(cmpl.closures)-1]
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	cmpl.curType = fn.CurType
+	goExpPop(cmpl)
+	// the closure is an operand of the expression
+	(*cmpl.states)[len(*cmpl.states)-1].Origin = &cmState{tkFn, cmExpOper, nil, nil, cfStopBack}
+	cmpl.dynamic = &cmState{tkToken, cmExpOper, nil, nil, 0}
+	block := &funcObj.Block
+	appendExp(cmpl, &core.CmdValue{Value: &core.Fn{Func: funcObj, Env: fn.Captures},
+		CmdCommon: core.CmdCommon{TokenID: block.TokenID},
+		Result:    closureType(cmpl, block.Vars[:block.ParCount], block.Result)})
+	return nil
+}
+
+// closureType returns the fn type with the specified parameters and result
+func closureType(cmpl *compiler, params []*core.TypeObject,
+	result *core.TypeObject) *core.TypeObject {
+	names := make([]string, len(params))
+	for i, par := range params {
+		names[i] = par.GetName()
+	}
+	name := closureName + `(` + strings.Join(names, `,`) + `)`
+	if result != nil {
+		name += result.GetName()
+	}
+	if obj := cmpl.unit.FindType(name); obj != nil {
+		return obj.(*core.TypeObject)
+	}
+	fnType := cmpl.unit.NewType(name, reflect.TypeOf(core.Fn{}), nil).(*core.TypeObject)
+	fnType.Func = &core.FnType{Params: append([]*core.TypeObject{}, params...), Result: result}
+	return fnType
+}
+
+// isFnAssignable returns true if the fn value can be assigned to the variable of the fn type.
+// The bare fn type of collections accepts fn values with any parameters.
+func isFnAssignable(left, right *core.TypeObject) bool {
+	if left == nil || right == nil || left.Original != reflect.TypeOf(core.Fn{}) {
+		return false
+	}
+	if left.Func == nil {
+		return right.Original == reflect.TypeOf(core.Fn{})
+	}
+	return isEqualTypes(left, right)
+}
+
+// getClosure returns the closure if the block is the block of the anonymous function
+func getClosure(cmpl *compiler, block *core.CmdBlock) *closure {
+	for _, fn := range cmpl.closures {
+		if fn.Block == block {
+			return fn
+		}
+	}
+	return nil
+}
+
+// boxType returns the type of the box for the variable
+func boxType(cmpl *compiler, typeObj *core.TypeObject) *core.TypeObject {
+	name := `arr.` + typeObj.GetName()
+	if obj := cmpl.unit.FindType(name); obj != nil {
+		return obj.(*core.TypeObject)
+	}
+	return cmpl.unit.NewType(name, reflect.TypeOf(core.Array{}), typeObj).(*core.TypeObject)
+}
+
+// boxItem returns the variable which is stored in the box
+func boxItem(cmpl *compiler, box *core.CmdVar) *core.CmdVar {
+	return &core.CmdVar{Block: box.Block, Index: box.Index, Indexes: []core.CmdRet{
+		{Cmd: &core.CmdValue{Value: int64(0), Result: cmpl.getIntType()},
+			Type: box.Block.Vars[box.Index].IndexOf}}}
+}
+
+// newBox moves the variable to the box if it has not yet been captured and returns the box
+func newBox(cmpl *compiler, block *core.CmdBlock, ind int) *core.CmdVar {
+	if box, ok := block.Captured[ind]; ok {
+		return &core.CmdVar{Block: block, Index: box.Index}
+	}
+	if block.Captured == nil {
+		block.Captured = make(map[int]*core.CmdVar)
+	}
+	box := &core.CmdVar{Block: block, Index: len(block.Vars)}
+	block.Vars = append(block.Vars, boxType(cmpl, block.Vars[ind]))
+	block.Captured[ind] = boxItem(cmpl, box)
+	return box
+}
+
+// capture passes the box of the variable through the closures and returns the variable
+// of the innermost closure
+func capture(cmpl *compiler, token string, box *core.CmdVar, closures []*closure) *core.CmdVar {
+	for i := len(closures) - 1; i >= 0; i-- {
+		fn := closures[i]
+		ind := len(fn.Block.Vars)
+		fn.Block.Vars = append(fn.Block.Vars, box.Block.Vars[box.Index])
+		fn.Env[token] = ind
+		fn.Captures = append(fn.Captures, core.Capture{Var: ind, Box: box})
+		box = &core.CmdVar{Block: fn.Block, Index: ind}
+	}
+	return boxItem(cmpl, box)
+}
+
+// findVar returns the variable with the specified name. The variables of the enclosing
+// functions are captured by closures
+func findVar(cmpl *compiler, token string) *core.CmdVar {
+	var closures []*closure
+	block := cmpl.curOwner()
+	for block != nil {
+		if ind, ok := block.VarNames[token]; ok {
+			if len(closures) == 0 {
+				return &core.CmdVar{Block: block, Index: ind}
+			}
+			return capture(cmpl, token, newBox(cmpl, block, ind), closures)
+		}
+		if fn := getClosure(cmpl, block); fn != nil {
+			if ind, ok := fn.Env[token]; ok {
+				return capture(cmpl, token, &core.CmdVar{Block: block, Index: ind}, closures)
+			}
+			closures = append(closures, fn)
+		}
+		block = block.Parent
+	}
+	return nil
+}
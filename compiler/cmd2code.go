@@ -25,6 +25,11 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			shift  int
 			locOut bool
 		)
+		if box, ok := cmdVar.Block.Captured[cmdVar.Index]; ok {
+			// the variable has been captured by a closure
+			cmdVar = &core.CmdVar{Block: box.Block, Index: box.Index, CmdCommon: cmdVar.CmdCommon,
+				Indexes: append(append([]core.CmdRet{}, box.Indexes...), cmdVar.Indexes...)}
+		}
 		block := cmdVar.Block
 		for shift = len(linker.Blocks) - 1; shift >= 0; shift-- {
 			if linker.Blocks[shift].Block == block {
@@ -84,6 +89,13 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 						push(ptypes...)
 					}
 				}
+				if embed.Return != nil && embed.Return.IsTuple() {
+					push(type2Code(embed.Return, out))
+					structOffset(out, len(out.Code)-1)
+				}
+				if embed.Generic != nil {
+					push(genericTypes(embed, cmd, out)...)
+				}
 			} else if embed.BCode.Code != nil {
 				code := embed.BCode.Code[0]
 				if code != core.NOP {
@@ -98,7 +110,11 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			anyFunc := cmd.(*core.CmdAnyFunc)
 			if anyFunc.IsThread {
 				block := obj.(*core.FuncObject).Block
-				push(core.Bcode(block.ParCount<<16)|core.GOBYID, core.Bcode(id))
+				var kept core.Bcode
+				if anyFunc.IsKept {
+					kept = core.GoKept
+				}
+				push(core.Bcode(block.ParCount<<16)|core.GOBYID|kept, core.Bcode(id))
 				for k := 0; k < block.ParCount; k++ {
 					ptype := type2Code(block.Vars[k], out)
 					push(core.Bcode(ptype))
@@ -155,7 +171,19 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 		obj := cmd.GetObject()
 		count := len(anyFunc.Children)
 		if obj == nil {
-			cmd2Code(linker, anyFunc.FnVar, out)
+			if method, ok := anyFunc.FnVar.(*core.CmdBlock); ok && method.ID == core.StackMethod {
+				var anyCount int
+				for _, param := range anyFunc.Children {
+					if type2Code(param.GetResult(), out)&0xf == core.STACKANY {
+						anyCount++
+					}
+				}
+				cmd2Code(linker, method.Children[0], out)
+				push(core.CALLIFACE, core.Bcode(anyCount))
+				getPos(linker, cmd, out)
+			} else {
+				cmd2Code(linker, anyFunc.FnVar, out)
+			}
 			push(core.Bcode(count<<16)|core.CALLBYID, 0)
 			getPos(linker, cmd, out)
 		} else if obj.GetType() == core.ObjEmbedded {
@@ -246,15 +274,14 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			push(core.Bcode(uint32(id)<<16) | core.PUSHSTR)
 		case *core.Fn:
 			id := v.Func.(*core.FuncObject).ObjID
-			push(core.PUSHFUNC, core.Bcode(id))
-			if out.Used == nil {
-				out.Used = make(map[int32]byte)
+			for _, item := range v.Env {
+				getIndex(item.Box, core.GETVAR)
 			}
-			if out.Used[id] == 0 {
-				genBytecode(v.Func.(*core.FuncObject).Unit.VM, id)
-				copyUsed(&v.Func.(*core.FuncObject).BCode, out)
-				out.Used[id] = 1
+			push(core.Bcode(len(v.Env)<<16)|core.PUSHFUNC, core.Bcode(id))
+			for _, item := range v.Env {
+				push(core.Bcode(item.Var))
 			}
+			useFunc(v.Func.(*core.FuncObject), out)
 		default:
 			fmt.Printf("CmdValue %T %v\n", cmd.(*core.CmdValue).Value, v)
 		}
@@ -357,7 +384,7 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			if rightType >= core.TYPESTRUCT {
 				structOffset(out, -len(out.Code)+1)
 			}
-		case core.StackQuestion:
+		case core.StackQuestion, core.StackSafe:
 			cmd2Code(linker, cmdStack.Children[0], out)
 			pos := len(out.Code)
 			push(core.JZE, 0)
@@ -452,9 +479,12 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			srcType := type2Code(cmdStack.Children[0].GetResult(), out)
 			curType := type2Code(cmdStack.Vars[0], out)
 			// we don't need to use structOffset because for doesn't support structs
+			// the source value follows the variables of the same stack
 			indcur := 0
-			if curType&0xf == core.STACKANY {
-				indcur = 1
+			for _, ivar := range cmdStack.Vars {
+				if type2Code(ivar, out)&0xf == srcType&0xf {
+					indcur++
+				}
 			}
 			pos := len(out.Code)
 			push(core.CYCLE)
@@ -468,6 +498,10 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 				core.Bcode(1<<16|core.INDEX), core.Bcode(int(srcType)<<16)|curType)
 			push(core.SETVAR, core.Bcode(int(curType)<<16|bInfo.Vars[0]),
 				core.Bcode(int(curType)<<16|core.ASSIGNPTR), core.Bcode(int(curType)<<16|core.POP))
+			if _, ok := cmdStack.Captured[0]; ok {
+				// every iteration has its own box
+				initBox(bInfo, 0, out)
+			}
 			blockStart := len(out.Code)
 			out.BlockFlags = core.BlContinue | core.BlBreak
 			cmd2Code(linker, cmdStack.Children[1], out)
@@ -542,7 +576,16 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			if retType >= core.TYPESTRUCT {
 				structOffset(out, -len(out.Code)+1)
 			}
+		case core.StackDefer:
+			deferCode(linker, cmdStack.Children[0].(*core.CmdBlock), 0, out)
 		case core.StackTry:
+			if len(cmdStack.Children) == 3 {
+				deferCode(linker, cmdStack.Children[2].(*core.CmdBlock), core.DeferFinally, out)
+				if cmdStack.Children[1] == nil { // try without catch
+					cmd2Code(linker, cmdStack.Children[0], out)
+					break
+				}
+			}
 			out.BlockFlags = core.BlTry
 			blockTry := len(out.Code)
 			cmd2Code(linker, cmdStack.Children[0], out)
@@ -555,6 +598,14 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
 			out.Code[blockCatch+1] = core.Bcode(len(out.Code) - blockCatch) // recover jump
 			out.Code[blockCatch+2] = core.Bcode(blockTry - blockCatch)      // retry jump
+		case core.StackTimeout:
+			cmd2Code(linker, cmdStack.Children[0], out)
+			push(core.TIMEOUT)
+			cmd2Code(linker, cmdStack.Children[1], out)
+			push(core.TIMEOUTEND)
+		case core.StackIface:
+			cmd2Code(linker, cmdStack.Children[0], out)
+			ifaceCode(cmdStack.Children[0].GetResult(), cmdStack.Result, out)
 		}
 	}
 }
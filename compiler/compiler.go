@@ -36,6 +36,9 @@ type compiler struct {
 	next        *cmState
 	dynamic     *cmState
 	goStack     []goStack
+	closures    []*closure
+	destructs   []destruct
+	binds       map[string]*core.TypeObject // the types of type parameters of the generic instance
 }
 
 type optInfo struct {
@@ -82,6 +85,7 @@ var (
 		tkCtxEq:                       {6, false, `CtxSet`},
 		tkAnd:                         {7, false, ``},
 		tkOr:                          {8, false, ``},
+		tkCoalesce:                    {9, false, ``},
 		tkEqual:                       {10, false, `Equal`},
 		tkNotEqual:                    {10, false, `Equal`},
 		tkLess:                        {10, false, `Less`},
@@ -175,6 +179,11 @@ func Compile(ws *core.Workspace, input, path string) (int, error) {
 				delete(ws.UnitNames, key)
 			}
 		}
+		for id, kind := range ws.ErrKinds {
+			if int(kind.ObjID) >= countObjects {
+				delete(ws.ErrKinds, id)
+			}
+		}
 
 		if v, ok := err.(int); ok {
 			err = cmpl.Error(v)
@@ -189,14 +198,43 @@ func Compile(ws *core.Workspace, input, path string) (int, error) {
 		cmpl.pos = len(lp.Tokens) - 1
 		return cmplError(errID)
 	}
+	if err := extractGenerics(cmpl); err != nil {
+		return cmplError(err)
+	}
+	if err := cmpl.compile(); err != nil {
+		return cmplError(err)
+	}
+
+	if cmpl.runID != core.Undefined {
+		cmpl.unit.RunID = cmpl.runID
+		if len(cmpl.unit.Name) == 0 {
+			cmpl.unit.Name = path
+		}
+		/*		if unitIndex, ok := ws.UnitNames[cmpl.unit.Name]; ok {
+				if ws.Units[unitIndex].Lexeme[0].Path != path {
+					fmt.Println(unitIndex, path, `LEX`, ws.Units[unitIndex].Lexeme[0].Path, `Name`, cmpl.unit.Name)
+					return cmplError(cmpl.Error(ErrLink, cmpl.unit.Name))
+				}
+			}*/
+	}
+	ws.Units = append(ws.Units, cmpl.unit)
+	unitID := len(ws.Units) - 1
+	ws.UnitNames[cmpl.unit.Name] = unitID
+	ws.Units[unitID].Index = uint32(unitID)
 
+	return unitID, nil
+}
+
+// compile compiles the tokens of the current lexeme
+func (cmpl *compiler) compile() error {
+	lp := cmpl.unit.Lexeme
 	stackState := make([]StateStack, 0, 32)
 	state := cmMain
 main:
 	for i := 0; i < len(lp.Tokens); i++ {
 		if cmpl.inits == 0 && lp.Tokens[i].Type == tkColon {
 			if err := colonToLine(cmpl, i); err != nil {
-				return cmplError(err)
+				return err
 			}
 		}
 		cmpl.pos = i
@@ -226,7 +264,7 @@ main:
 		if state == cmExp && token.Type == tkIdent {
 			isOpt, err := coOptionalFunc(cmpl)
 			if err != nil {
-				return cmplError(err)
+				return err
 			}
 			if isOpt {
 				i = cmpl.newPos
@@ -235,7 +273,7 @@ main:
 		}
 		if cmpl.next.Func != nil {
 			if err := cmpl.next.Func(cmpl); err != nil {
-				return cmplError(err)
+				return err
 			}
 			if cmpl.newPos != 0 {
 				i = cmpl.newPos
@@ -257,7 +295,7 @@ main:
 		}
 		if cmpl.next.State == cmBack {
 			if len(stackState) == 0 {
-				return cmplError(cmpl.Error(ErrCompiler, `Compile`))
+				return cmpl.Error(ErrCompiler, `Compile`)
 			}
 			for len(stackState) > 0 {
 				prev := stackState[len(stackState)-1]
@@ -265,7 +303,7 @@ main:
 				if prev.Origin.Callback != nil {
 					//cmpl.pos = prev.Pos
 					if err := prev.Origin.Callback(cmpl); err != nil {
-						return cmplError(err)
+						return err
 					}
 					if cmpl.dynamic != nil {
 						stackState = append(stackState, StateStack{Origin: cmpl.dynamic, Pos: i, State: state})
@@ -287,27 +325,9 @@ main:
 		state = cmpl.next.State
 	}
 	if len(stackState) > 0 {
-		return cmplError(cmpl.ErrorPos(len(lp.Tokens), ErrEnd))
-	}
-
-	if cmpl.runID != core.Undefined {
-		cmpl.unit.RunID = cmpl.runID
-		if len(cmpl.unit.Name) == 0 {
-			cmpl.unit.Name = path
-		}
-		/*		if unitIndex, ok := ws.UnitNames[cmpl.unit.Name]; ok {
-				if ws.Units[unitIndex].Lexeme[0].Path != path {
-					fmt.Println(unitIndex, path, `LEX`, ws.Units[unitIndex].Lexeme[0].Path, `Name`, cmpl.unit.Name)
-					return cmplError(cmpl.Error(ErrLink, cmpl.unit.Name))
-				}
-			}*/
+		return cmpl.ErrorPos(len(lp.Tokens), ErrEnd)
 	}
-	ws.Units = append(ws.Units, cmpl.unit)
-	unitID := len(ws.Units) - 1
-	ws.UnitNames[cmpl.unit.Name] = unitID
-	ws.Units[unitID].Index = uint32(unitID)
-
-	return unitID, nil
+	return nil
 }
 
 func colonToLine(cmpl *compiler, i int) error {
@@ -344,8 +364,13 @@ func isEqualTypes(left *core.TypeObject, right *core.TypeObject) bool {
 	}
 	switch left.Original {
 	case reflect.TypeOf(core.Fn{}):
-		if right.Original != reflect.TypeOf(core.Fn{}) ||
-			len(left.Func.Params) != len(right.Func.Params) ||
+		if right.Original != reflect.TypeOf(core.Fn{}) {
+			return false
+		}
+		if left.Func == nil || right.Func == nil {
+			return left.Func == right.Func
+		}
+		if len(left.Func.Params) != len(right.Func.Params) ||
 			!isEqualTypes(left.Func.Result, right.Func.Result) {
 			return false
 		}
@@ -372,16 +397,47 @@ func isEqualTypes(left *core.TypeObject, right *core.TypeObject) bool {
 		if left.IndexOf == nil || right.IndexOf == nil {
 			return true
 		}
-		return isEqualTypes(left.IndexOf, right.IndexOf)
+		return left.KeyOf == right.KeyOf && isEqualTypes(left.IndexOf, right.IndexOf)
+	case reflect.TypeOf(core.Struct{}):
+		// tuples with the same types of items are equal
+		if left.IsTuple() && right.IsTuple() {
+			if len(left.Custom.Types) != len(right.Custom.Types) {
+				return false
+			}
+			for i, item := range left.Custom.Types {
+				if !isEqualTypes(item, right.Custom.Types[i]) {
+					return false
+				}
+			}
+			return true
+		}
 	}
 	return left == right
 }
 
 func autoType(cmpl *compiler, name string) (obj core.IObject, err error) {
+	if cmpl.binds != nil {
+		if typeObj := bindType(cmpl, name, cmpl.binds); typeObj != nil {
+			return typeObj, nil
+		}
+	}
 	if strings.HasSuffix(name, `.arr`) || strings.HasSuffix(name, `.map`) {
 		name += `.str`
 	}
 	obj = cmpl.unit.FindType(name)
+	if key, item, ok := core.SplitMapKey(name); ok && obj == nil {
+		switch key {
+		case `str`:
+			return autoType(cmpl, `map.`+item)
+		case `int`:
+			var indexOf core.IObject
+			if indexOf, err = autoType(cmpl, item); indexOf != nil {
+				return cmpl.unit.NewMapType(name, indexOf, cmpl.getIntType()), nil
+			}
+			return
+		}
+		return nil, cmpl.Error(ErrType)
+	}
 	if obj == nil {
 		ins := strings.SplitN(name, `.`, 2)
 		if len(ins) == 2 {
@@ -401,6 +457,15 @@ func autoType(cmpl *compiler, name string) (obj core.IObject, err error) {
 						return
 					}
 				}
+			} else if ins[0] == `thread` {
+				// thread.T keeps the result type of the go block
+				var indexOf core.IObject
+				indexOf, err = autoType(cmpl, ins[1])
+				if indexOf != nil {
+					if obj = cmpl.unit.NewType(name, reflect.TypeOf(int64(0)), indexOf); obj != nil {
+						return
+					}
+				}
 			}
 		}
 		return nil, cmpl.Error(ErrType)
@@ -420,17 +485,6 @@ func getType(cmpl *compiler) (obj core.IObject, err error) {
 	return autoType(cmpl, getToken(cmpl.unit.Lexeme, cmpl.pos))
 }
 
-func findVar(cmpl *compiler, token string) (*core.CmdBlock, int) {
-	block := cmpl.curOwner()
-	for block != nil {
-		if ind, ok := block.VarNames[token]; ok {
-			return block, ind
-		}
-		block = block.Parent
-	}
-	return nil, 0
-}
-
 func coError(cmpl *compiler) error {
 	return cmpl.Error(cmpl.next.State)
 }
@@ -556,6 +610,9 @@ func coVarExp(cmpl *compiler) error {
 	if len(tokens) == cmpl.pos+1 {
 		return nil
 	}
+	if tokens[cmpl.pos+1].Type == tkComma {
+		return coDestruct(cmpl)
+	}
 	if tokens[cmpl.pos+1].Type == tkAssign || tokens[cmpl.pos+1].Type == tkBitAndEq {
 		block := cmpl.curOwner()
 		if cmpl.curOptional {
@@ -613,8 +670,18 @@ func coExpEnv(cmpl *compiler) error {
 	return nil
 }
 
+// funcOwners returns the owners of the current function
+func funcOwners(cmpl *compiler) []core.ICmd {
+	for i := len(cmpl.owners) - 1; i > 0; i-- {
+		if cmpl.owners[i].(*core.CmdBlock).Object != nil {
+			return cmpl.owners[i:]
+		}
+	}
+	return cmpl.owners
+}
+
 func isInLoop(cmpl *compiler, incase bool) bool {
-	for _, item := range cmpl.owners {
+	for _, item := range jumpOwners(cmpl) {
 		if item.GetType() == core.CtStack {
 			id := item.(*core.CmdBlock).ID
 			if id == core.StackWhile || id == core.StackFor ||
@@ -42,10 +42,15 @@ const (
 	cmInclude     // include command
 	cmIncludeFile // include file
 	cmGo          // go command
+	cmClosure     // anonymous function
 	cmLocal       // local command
 	cmLocalParams
 	cmCatch // catch command
 	cmCatchIdent
+	cmFinally      // finally command
+	cmIface        // interface definition
+	cmIfaceDef     // interface body
+	cmIfaceMethods // methods of the interface
 
 	cmBack // go to back
 
@@ -74,9 +79,12 @@ var (
 			{tkFunc, cmFunc, nil, coFuncBack, cfStopBack},
 			{tkStruct, cmStruct, nil, nil, cfStopBack},
 			{tkFn, cmFn, nil, nil, cfStopBack},
+			{tkInterface, cmIface, nil, nil, cfStopBack},
+			{tkEnum, 0, coEnum, nil, 0},
 			{tkInclude, cmInclude, coInclude, nil, cfStopBack},
 			{tkImport, cmInclude, coImport, nil, cfStopBack},
 			{tkPub, 0, coPub, nil, 0},
+			{tkIdent, 0, coErrorKind, nil, 0},
 		},
 		cmRun: {
 			{tkToken, ErrLCurly, coError, nil, 0},
@@ -106,6 +114,8 @@ var (
 			{tkRetry, 0, coRetry, nil, 0},
 			{tkLocal, cmLocal, nil, coLocalBack, cfStopBack},
 			{tkTry, cmLCurly, coTry, coTryBack, cfStopBack},
+			{tkTimeout, cmExp, coTimeout, coTimeoutBack, cfStopBack},
+			{tkDefer, cmLCurly, coDefer, coDeferBack, cfStopBack},
 		},
 		cmExp: {
 			{tkToken, ErrValue, coError, nil, 0},
@@ -123,6 +133,7 @@ var (
 			{tkEnv, cmExpOper, coExpEnv, nil, cfStopBack},
 			{tkQuestion, cmExpIdent, nil, nil, cfStopBack},
 			{tkGo, cmGo, coGo, coGoBack, cfStopBack},
+			{tkFn, cmClosure, coClosure, coClosureBack, cfStopBack},
 		},
 		cmExpIdent: {
 			{tkToken, cmExpOper, coExpVar, nil, cfStay},
@@ -135,8 +146,9 @@ var (
 			{[]int{tkAdd, tkDiv, tkMod, tkMul, tkSub, tkEqual, tkNotEqual, tkGreater, tkGreaterEqual,
 				tkLess, tkLessEqual, tkAssign, tkOr, tkAnd, tkBitOr, tkBitAnd, tkBitXor, tkLShift,
 				tkRShift, tkAddEq, tkSubEq, tkMulEq, tkDivEq, tkModEq, tkLShiftEq, tkRShiftEq,
-				tkBitAndEq, tkBitOrEq, tkBitXorEq, tkRange, tkCtxEq, tkDot}, cmBack,
+				tkBitAndEq, tkBitOrEq, tkBitXorEq, tkRange, tkCtxEq, tkDot, tkCoalesce}, cmBack,
 				coOperator, nil, 0},
+			{tkSafeDot, 0, coSafeDot, nil, 0},
 			{[]int{tkInc, tkDec, tkQuestion}, 0, coUnaryPostOperator, nil, 0},
 			{[]int{tkRPar, tkRSBracket}, 0, coOperator, nil, 0},
 			{[]int{tkLSBracket}, cmBack, coIndex, nil, cfStay},
@@ -157,7 +169,7 @@ var (
 		cmParams: {
 			{tkToken, ErrLCurly, coError, nil, 0},
 			{tkIdent, cmLCurly, coRetType, nil, 0},
-			{tkLPar, cmParam, nil, nil, cfStopBack},
+			{tkLPar, cmParam, coFuncTuple, nil, cfStopBack},
 			{tkLCurly, cmLCurly, coFuncStart, nil, cfStay},
 			{tkLine, 0, nil, nil, 0},
 		},
@@ -258,6 +270,22 @@ var (
 			{tkRCurly, cmBack, nil, nil, cfStay},
 			{tkLine, cmBack, nil, nil, 0},
 		},
+		cmIface: {
+			{tkToken, ErrName, coError, nil, 0},
+			{tkLine, 0, nil, nil, 0},
+			{tkIdent, cmIfaceDef, coIface, coIfaceEnd, 0},
+		},
+		cmIfaceDef: {
+			{tkToken, ErrLCurly, coError, nil, 0},
+			{tkLCurly, cmIfaceMethods, nil, nil, 0},
+			{tkLine, 0, nil, nil, 0},
+		},
+		cmIfaceMethods: {
+			{tkToken, ErrName, coError, nil, 0},
+			{tkLine, 0, nil, nil, 0},
+			{tkIdent, 0, coIfaceMethod, nil, 0},
+			{tkRCurly, cmBack, nil, nil, 0},
+		},
 		cmFn: {
 			{tkToken, ErrName, coError, nil, 0},
 			{tkIdent, cmFnParams, coFn, coFnEnd, 0},
@@ -266,7 +294,7 @@ var (
 		cmFnParams: {
 			{tkToken, ErrNewLine, coError, nil, 0},
 			{tkIdent, cmBack, coFnResult, nil, 0},
-			{tkLPar, cmFnParam, nil, nil, cfStopBack},
+			{tkLPar, cmFnParam, coFnTuple, nil, cfStopBack},
 			{tkLine, cmBack, nil, nil, cfStay},
 		},
 		cmFnParam: {
@@ -303,6 +331,13 @@ var (
 			{tkLPar, cmExp, coGoParams, nil, cfStopBack | cfStay},
 			{tkLine, 0, nil, nil, 0},
 		},
+		cmClosure: {
+			{tkToken, ErrLCurly, coError, nil, 0},
+			{tkIdent, cmLCurly, coClosureResult, nil, 0},
+			{tkLPar, cmParam, coClosureTuple, nil, cfStopBack},
+			{tkLCurly, cmLCurly, coClosureStart, nil, cfStay},
+			{tkLine, 0, nil, nil, 0},
+		},
 		cmLocal: {
 			{tkToken, ErrName, coError, nil, 0},
 			{tkIdent, cmLocalParams, coLocalName, nil, 0},
@@ -311,19 +346,25 @@ var (
 		cmLocalParams: {
 			{tkToken, ErrLCurly, coError, nil, 0},
 			{tkIdent, cmLCurly, coLocalRetType, nil, 0},
-			{tkLPar, cmParam, nil, nil, cfStopBack},
+			{tkLPar, cmParam, coLocalTuple, nil, cfStopBack},
 			{tkLCurly, cmLCurly, coLocalStart, nil, cfStay},
 			{tkLine, 0, nil, nil, 0},
 		},
 		cmCatch: {
 			{tkToken, ErrCatch, coError, nil, 0},
 			{tkLine, 0, nil, nil, 0},
-			{tkCatch, cmCatchIdent, nil, coCatchBack, 0},
+			{tkCatch, cmCatchIdent, nil, nil, 0},
+			{tkFinally, cmLCurly, coFinally, nil, 0},
 		},
 		cmCatchIdent: {
 			{tkToken, ErrName, coError, nil, 0},
 			{tkIdent, cmLCurly, coCatch, nil, 0},
 		},
+		cmFinally: {
+			{tkToken, cmBack, nil, nil, cfStay},
+			{tkLine, 0, nil, nil, 0},
+			{tkFinally, cmLCurly, coFinally, nil, 0},
+		},
 	}
 	compileTable [][tkToken]*cmState
 )
@@ -5,6 +5,9 @@
 package compiler
 
 import (
+	"strings"
+	"unicode"
+
 	"github.com/gentee/gentee/core"
 )
 
@@ -100,3 +103,55 @@ func coConstList(cmpl *compiler) error {
 
 	return nil
 }
+
+// coErrorKind declares the kinds of errors as int constants with unique values
+// error Name1, Name2, ...
+func coErrorKind(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if getToken(lp, cmpl.pos) != `error` {
+		return cmpl.Error(ErrDecl)
+	}
+	i := cmpl.pos + 1
+	for {
+		if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkIdent {
+			return cmpl.ErrorPos(i, ErrName)
+		}
+		name := getToken(lp, i)
+		if !unicode.IsUpper([]rune(name)[0]) || strings.IndexRune(name, '.') >= 0 {
+			return cmpl.ErrorPos(i, ErrErrorKind)
+		}
+		if cmpl.unit.FindConst(name) != nil {
+			return cmpl.ErrorPos(i, ErrConstDef, name)
+		}
+		id := core.ErrKindID(name)
+		if kind, ok := cmpl.ws.ErrKinds[id]; ok && kind.Name != name {
+			return cmpl.ErrorPos(i, ErrErrorKindID, name, kind.Name)
+		}
+		constObj := &core.ConstObject{
+			Object: core.Object{
+				Name: name,
+				Unit: cmpl.unit,
+				Pub:  cmpl.unit.Pub != 0,
+			},
+			Redefined: false,
+			Exp: &core.CmdValue{Value: int64(id),
+				CmdCommon: core.CmdCommon{TokenID: uint32(i)}, Result: cmpl.getIntType()},
+			Return: cmpl.getIntType(),
+			Iota:   core.NotIota,
+		}
+		constObj.ObjID = int32(cmpl.appendObj(constObj))
+		cmpl.unit.AddConst(name)
+		if _, ok := cmpl.ws.ErrKinds[id]; !ok {
+			cmpl.ws.ErrKinds[id] = constObj
+		}
+		if i+1 >= len(lp.Tokens) || lp.Tokens[i+1].Type != tkComma {
+			break
+		}
+		i += 2
+	}
+	cmpl.newPos = i
+	if cmpl.unit.Pub == core.PubOne {
+		cmpl.unit.Pub = 0
+	}
+	return nil
+}
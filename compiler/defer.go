@@ -0,0 +1,81 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"github.com/gentee/gentee/core"
+)
+
+func coDefer(cmpl *compiler) error {
+	owner := cmpl.curOwner()
+	if owner.Object == nil && (owner.Parent == nil || (owner.Parent.ID != core.StackLocal &&
+		owner.Parent.ID != core.StackDefer)) {
+		return cmpl.Error(ErrDefer)
+	}
+	cmd := core.CmdBlock{ID: core.StackDefer, CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	appendCmd(cmpl, &cmd)
+	cmdBlock := core.CmdBlock{ID: core.StackBlock, Parent: &cmd,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	cmd.Children = append(cmd.Children, &cmdBlock)
+	cmpl.owners = append(cmpl.owners, &cmdBlock)
+	return nil
+}
+
+func coDeferBack(cmpl *compiler) error {
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	return nil
+}
+
+// isDeferBlock returns true if the command is the block of defer or finally
+func isDeferBlock(item core.ICmd) bool {
+	if item.GetType() != core.CtStack {
+		return false
+	}
+	parent := item.(*core.CmdBlock).Parent
+	return parent != nil && (parent.ID == core.StackDefer ||
+		(parent.ID == core.StackTry && len(parent.Children) == 3 && item == parent.Children[2]))
+}
+
+// isInDefer returns true if the current command is inside defer or finally
+// and it is not inside a local function
+func isInDefer(cmpl *compiler) bool {
+	owners := funcOwners(cmpl)
+	for i := len(owners) - 1; i >= 0; i-- {
+		if isDeferBlock(owners[i]) {
+			return true
+		}
+		if parent := owners[i].(*core.CmdBlock).Parent; parent != nil &&
+			parent.ID == core.StackLocal {
+			break
+		}
+	}
+	return false
+}
+
+// jumpOwners returns the owners which can be left by break, continue, recover and retry.
+// The blocks of defer and finally can't be left by these commands
+func jumpOwners(cmpl *compiler) []core.ICmd {
+	owners := funcOwners(cmpl)
+	for i := len(owners) - 1; i >= 0; i-- {
+		if isDeferBlock(owners[i]) {
+			return owners[i:]
+		}
+	}
+	return owners
+}
+
+// deferCode appends the deferred block which is called when the function or try is left
+func deferCode(linker *Linker, block *core.CmdBlock, flags int, out *core.Bytecode) {
+	linker.Blocks = append(linker.Blocks, BlockInfo{
+		Block:   block,
+		IsLocal: true,
+	})
+	pos := len(out.Code)
+	out.Code = append(out.Code, core.Bcode(flags<<16)|core.DEFER, 0)
+	cmd2Code(linker, block, out)
+	out.Code = append(out.Code, core.END)
+	out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
+	linker.Blocks = linker.Blocks[:len(linker.Blocks)-1]
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gentee/gentee/core"
+)
+
+// coEnum declares the enum type and its items as the constants of this type
+// enum Name { Item1, Item2 ... }
+func coEnum(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	i := cmpl.pos + 1
+	if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkIdent {
+		return cmpl.ErrorPos(i, ErrName)
+	}
+	cmpl.pos = i
+	token, err := checkNewType(cmpl)
+	if err != nil {
+		return err
+	}
+	pub := cmpl.unit.Pub != 0
+	pType := cmpl.unit.NewType(token, reflect.TypeOf(int64(0)), nil).(*core.TypeObject)
+	pType.Enum = make([]string, 0)
+	if i++; i >= len(lp.Tokens) || lp.Tokens[i].Type != tkLCurly {
+		return cmpl.ErrorPos(i, ErrLCurly)
+	}
+	for i++; i < len(lp.Tokens) && lp.Tokens[i].Type != tkRCurly; i++ {
+		switch lp.Tokens[i].Type {
+		case tkLine, tkComma:
+			continue
+		case tkIdent:
+		default:
+			return cmpl.ErrorPos(i, ErrName)
+		}
+		name := getToken(lp, i)
+		if !unicode.IsUpper([]rune(name)[0]) || strings.IndexRune(name, '.') >= 0 {
+			return cmpl.ErrorPos(i, ErrEnumItem)
+		}
+		if cmpl.unit.FindConst(name) != nil {
+			return cmpl.ErrorPos(i, ErrConstDef, name)
+		}
+		constObj := &core.ConstObject{
+			Object: core.Object{
+				Name: name,
+				Unit: cmpl.unit,
+				Pub:  pub,
+			},
+			Redefined: false,
+			Exp: &core.CmdValue{Value: int64(len(pType.Enum)),
+				CmdCommon: core.CmdCommon{TokenID: uint32(i)}, Result: pType},
+			Return: pType,
+			Iota:   core.NotIota,
+		}
+		constObj.ObjID = int32(cmpl.appendObj(constObj))
+		cmpl.unit.AddConst(name)
+		pType.Enum = append(pType.Enum, name)
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(i, ErrEnd)
+	}
+	if len(pType.Enum) == 0 {
+		return cmpl.ErrorPos(i, ErrName)
+	}
+	cmpl.newPos = i
+	return nil
+}
+
+// enumNames returns the value with the names of enum items like Type:Item1,Item2
+func enumNames(cmpl *compiler, enumType *core.TypeObject, pos int) core.ICmd {
+	return &core.CmdValue{Value: enumType.GetName() + `:` + strings.Join(enumType.Enum, `,`),
+		CmdCommon: core.CmdCommon{TokenID: uint32(pos)}, Result: cmpl.getStrType()}
+}
+
+// enumToStr converts the enum value to the name of its item
+func enumToStr(cmpl *compiler, icmd core.ICmd) core.ICmd {
+	enumType := icmd.GetResult()
+	if enumType == nil || enumType.Enum == nil {
+		return icmd
+	}
+	obj := getFunc(cmpl, `sysEnumStr`, []*core.TypeObject{enumType, cmpl.getStrType()})
+	return &core.CmdBinary{CmdCommon: core.CmdCommon{TokenID: uint32(icmd.GetToken())},
+		Object: obj, Result: obj.Result(), Left: icmd,
+		Right: enumNames(cmpl, enumType, icmd.GetToken())}
+}
+
+// enumPrint converts the enum arguments of Print, Println and Format to the names of their
+// items if the variadic function of the standard library is called
+func enumPrint(cmpl *compiler, name string, args []core.ICmd, params []*core.TypeObject) {
+	if name != `Print` && name != `Println` && name != `Format` {
+		return
+	}
+	if embed, ok := getFunc(cmpl, name, params).(*core.EmbedObject); !ok || !embed.Variadic {
+		return
+	}
+	for i, arg := range args {
+		args[i] = enumToStr(cmpl, arg)
+		params[i] = args[i].GetResult()
+	}
+}
+
+// enumFunc returns the conversion str(enum) or EnumType(str). It returns nil if the call is
+// not the enum conversion
+func enumFunc(cmpl *compiler, pos int, name string, args []core.ICmd) core.ICmd {
+	if len(args) != 1 || args[0].GetResult() == nil {
+		return nil
+	}
+	if name == `str` {
+		if args[0].GetResult().Enum == nil {
+			return nil
+		}
+		return enumToStr(cmpl, args[0])
+	}
+	obj, _ := cmpl.unit.FindType(name).(*core.TypeObject)
+	if obj == nil || obj.Enum == nil || args[0].GetResult().GetName() != `str` {
+		return nil
+	}
+	fnObj := getFunc(cmpl, `sysEnumInt`, []*core.TypeObject{cmpl.getStrType(),
+		cmpl.getStrType()})
+	return &core.CmdBinary{CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+		Object: fnObj, Result: obj, Left: args[0], Right: enumNames(cmpl, obj, pos)}
+}
+
+// enumItems returns the array of all items of the enum type
+func enumItems(cmpl *compiler, enumType *core.TypeObject, pos int) core.ICmd {
+	cmd := &core.CmdBlock{ID: core.StackNew, CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+		Result: cmpl.unit.NameToType(`arr.` + enumType.GetName()).(*core.TypeObject)}
+	for i := range enumType.Enum {
+		cmd.Children = append(cmd.Children, &core.CmdValue{Value: int64(i),
+			CmdCommon: core.CmdCommon{TokenID: uint32(pos)}, Result: enumType})
+	}
+	return cmd
+}
+
+// enumSwitch checks that the switch over the enum value has cases for all items
+// or the default block
+func enumSwitch(cmpl *compiler, cmd *core.CmdBlock) error {
+	if len(cmd.Children) == 0 {
+		return nil
+	}
+	enumType := cmd.Children[0].GetResult()
+	if enumType == nil || enumType.Enum == nil {
+		return nil
+	}
+	used := make([]bool, len(enumType.Enum))
+	for _, item := range cmd.Children[1:] {
+		caseStack := item.(*core.CmdBlock)
+		if caseStack.ID == core.StackDefault {
+			return nil
+		}
+		for _, icmd := range caseStack.Children[:len(caseStack.Children)-1] {
+			if icmd.GetType() != core.CtConst {
+				// the case is not a constant so we can't check it
+				return nil
+			}
+			constObj := icmd.GetObject().(*core.ConstObject)
+			if value, ok := constObj.Exp.(*core.CmdValue); ok {
+				if ind, ok := value.Value.(int64); ok && ind >= 0 && ind < int64(len(used)) {
+					used[ind] = true
+				}
+			}
+		}
+	}
+	for i, name := range enumType.Enum {
+		if !used[i] {
+			return cmpl.ErrorPos(cmd.GetToken(), ErrEnumSwitch, name, enumType.GetName())
+		}
+	}
+	return nil
+}
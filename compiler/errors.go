@@ -181,6 +181,34 @@ const (
 	ErrFnBuildIn
 	// ErrFnVariadic is returned when fn variable assigned to variadic function
 	ErrFnVariadic
+	// ErrGenericType is returned when the type parameter of the generic function is wrong
+	ErrGenericType
+	// ErrGenericUnused is returned when the type parameter is not used in parameters
+	ErrGenericUnused
+	// ErrGenericVar is returned when there is a variadic parameter in the generic function
+	ErrGenericVar
+	// ErrSafeDot is returned when ?. operator is applied to the wrong value
+	ErrSafeDot
+	// ErrCoalesce is returned when ?? operator has wrong operands
+	ErrCoalesce
+	// ErrDefer is returned when defer is placed outside of the body of the function
+	ErrDefer
+	// ErrDeferReturn is returned when return is placed inside defer or finally
+	ErrDeferReturn
+	// ErrErrorKind is returned when the name of the error kind is invalid
+	ErrErrorKind
+	// ErrErrorKindID is returned when the identifier of the error kind matches another kind
+	ErrErrorKindID
+	// ErrIfaceMethod is returned when the method of the interface has already been defined
+	ErrIfaceMethod
+	// ErrIfaceCall is returned when the interface doesn't have the called method
+	ErrIfaceCall
+	// ErrIfaceImpl is returned when the struct type doesn't implement the interface
+	ErrIfaceImpl
+	// ErrEnumItem is returned when the name of the enum item is invalid
+	ErrEnumItem
+	// ErrEnumSwitch is returned when switch doesn't have cases for all items of the enum
+	ErrEnumSwitch
 
 	// ErrCompiler error. It means a bug.
 	ErrCompiler
@@ -278,12 +306,26 @@ var (
 		ErrLocalName:     `%s local function has already been defined`,
 		ErrLocalVariadic: `local function cannot have a variadic parameter`,
 		ErrGoParam:       `there is an unnamed parameter in go statement`,
-		ErrCatch:         `unexpected token, expecting 'catch'`,
+		ErrCatch:         `unexpected token, expecting 'catch' or 'finally'`,
 		ErrRecover:       `'recover' can only be inside catch`,
 		ErrRetry:         `'retry' can only be inside catch`,
 		ErrLinkIndex:     `incorrect link index %d`,
 		ErrFnBuildIn:     `fn variable can't be assigned to a built-in function`,
 		ErrFnVariadic:    `fn variable can't be assigned to a variadic function`,
+		ErrGenericType:   `unexpected token, expecting the name of the type parameter`,
+		ErrGenericUnused: `type parameter %s is not used in parameters`,
+		ErrGenericVar:    `generic function cannot have a variadic parameter`,
+		ErrSafeDot:       `operator ?. is not supported for %s`,
+		ErrCoalesce:      `operator ?? is not supported for %s and %s`,
+		ErrDefer:         `'defer' can only be inside the body of the function`,
+		ErrDeferReturn:   `'return' cannot be inside defer or finally`,
+		ErrErrorKind:     `the name of the error kind must begin with a capital letter`,
+		ErrErrorKindID:   `the identifier of %s error kind is the same as %s`,
+		ErrIfaceMethod:   `%s method has already been defined`,
+		ErrIfaceCall:     `method %s has not been found in %s interface`,
+		ErrIfaceImpl:     `%s does not implement %s (missing method %s)`,
+		ErrEnumItem:      `the name of the enum item must begin with a capital letter`,
+		ErrEnumSwitch:    `switch doesn't handle %s of %s`,
 
 		ErrCompiler: `you have found a compiler bug [%s]. Let us know, please`,
 	}
@@ -111,12 +111,33 @@ func coExpVar(cmpl *compiler) error {
 			fields = fields[1:]
 		}
 
-		block, ind := findVar(cmpl, token)
-		if block == nil {
+		cmdVar := findVar(cmpl, token)
+		if cmdVar == nil {
+			if constObj := cmpl.unit.FindConst(token); constObj != nil && len(fields) == 0 {
+				// the kind of errors
+				appendExp(cmpl, &core.CmdConst{
+					Object:    constObj,
+					CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos - 1)},
+				})
+				return nil
+			}
+			if obj := cmpl.unit.FindType(token); obj != nil && len(fields) == 0 &&
+				obj.(*core.TypeObject).Enum != nil {
+				// the name of the enum type is the array of all its items
+				appendExp(cmpl, enumItems(cmpl, obj.(*core.TypeObject), cmpl.pos-1))
+				return nil
+			}
+			if obj := cmpl.unit.FindType(token); obj != nil && len(fields) == 0 &&
+				obj.(*core.TypeObject).Custom != nil && isTypeofCase(cmpl) {
+				// the name of the struct type is compared with the result of typeof
+				appendExp(cmpl, &core.CmdValue{Value: obj.GetName(),
+					CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos - 1)},
+					Result:    cmpl.getStrType()})
+				return nil
+			}
 			return cmpl.ErrorPos(cmpl.pos-1, ErrUnknownIdent, token)
 		}
-		cmdVar := &core.CmdVar{Block: block, Index: ind,
-			CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos - 1)}}
+		cmdVar.TokenID = uint32(cmpl.pos - 1)
 		typeVar := cmdVar.GetResult()
 		for _, field := range fields {
 			indField, typeField, err := structIndex(cmpl, typeVar, field)
@@ -141,12 +162,16 @@ func coExpEnd(cmpl *compiler) error {
 		}
 	}
 	init := isInState(cmpl, cmInit, 0)
-	if len(cmpl.exp) > 1 && !init && !isCase(cmpl) {
+	if len(cmpl.exp) > 1 && !init && !isCase(cmpl) && !isReturn(cmpl) {
 		return cmpl.Error(ErrCompiler, `coExpEnd`)
 	}
 	for len(cmpl.exp) > 0 {
-		cmpl.curOwner().Children = append(cmpl.curOwner().Children, cmpl.exp[0])
+		icmd := cmpl.exp[0]
+		cmpl.curOwner().Children = append(cmpl.curOwner().Children, icmd)
 		cmpl.exp = cmpl.exp[1:]
+		if err := destructEnd(cmpl, icmd); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -178,7 +203,7 @@ func popBuf(cmpl *compiler) error {
 		if len(cmpl.exp) < 2 {
 			return cmpl.Error(ErrValue)
 		}
-		right := cmpl.exp[len(cmpl.exp)-1]
+		right := enumToStr(cmpl, cmpl.exp[len(cmpl.exp)-1])
 		left := cmpl.exp[len(cmpl.exp)-2]
 		obj = getOperator(cmpl, prior.Name, left, right)
 		if obj == nil {
@@ -191,6 +216,17 @@ func popBuf(cmpl *compiler) error {
 		cmpl.exp = cmpl.exp[:len(cmpl.exp)-1]
 	case tkDot:
 		return cmpl.ErrorPos(expBuf.Pos, ErrOper)
+	case tkCoalesce:
+		if len(cmpl.exp) < 2 {
+			return cmpl.Error(ErrValue)
+		}
+		icmd, err := coalesce(cmpl, cmpl.exp[len(cmpl.exp)-2], cmpl.exp[len(cmpl.exp)-1],
+			expBuf.Pos)
+		if err != nil {
+			return err
+		}
+		cmpl.exp[len(cmpl.exp)-2] = icmd
+		cmpl.exp = cmpl.exp[:len(cmpl.exp)-1]
 	case tkAssign, tkAddEq, tkSubEq, tkMulEq, tkDivEq, tkModEq, tkLShiftEq, tkRShiftEq, tkBitAndEq,
 		tkBitOrEq, tkBitXorEq:
 		if len(cmpl.exp) < 2 {
@@ -223,17 +259,42 @@ func popBuf(cmpl *compiler) error {
 		if obj == nil {
 			if expBuf.Oper == tkAssign {
 				if left.GetResult().Custom != nil {
-					if left.GetResult() != right.GetResult() {
+					if !isEqualTypes(left.GetResult(), right.GetResult()) {
 						return cmpl.ErrorPos(expBuf.Pos, ErrStructAssign, right.GetResult().GetName(),
 							left.GetResult().GetName())
 					}
 					obj = cmpl.ws.StdLib().FindObj(core.DefAssignStructStruct)
+				} else if left.GetResult().Iface != nil {
+					if right.GetResult().Custom != nil {
+						method, ok := implements(cmpl, right.GetResult(), left.GetResult())
+						if !ok {
+							return cmpl.ErrorPos(expBuf.Pos, ErrIfaceImpl, right.GetResult().GetName(),
+								left.GetResult().GetName(), method)
+						}
+						right = toIface(cmpl, left.GetResult(), right)
+					}
+					if !isEqualTypes(left.GetResult(), right.GetResult()) {
+						return cmpl.ErrorPos(expBuf.Pos, ErrStructAssign, right.GetResult().GetName(),
+							left.GetResult().GetName())
+					}
+					obj = cmpl.ws.StdLib().FindObj(core.DefAssignIfaceIface)
 				} else if left.GetResult().Func != nil {
 					if !isEqualTypes(left.GetResult(), right.GetResult()) {
 						return cmpl.ErrorPos(expBuf.Pos, ErrStructAssign, right.GetResult().GetName(),
 							left.GetResult().GetName())
 					}
 					obj = cmpl.ws.StdLib().FindObj(core.DefAssignFnFn)
+				} else if left.GetResult().GetName() == `thread` || isTypedThread(left.GetResult()) {
+					if !isThreadAssignable(left.GetResult(), right) {
+						rightName := right.GetResult().GetName()
+						if result, ok := goResult(right); ok && result != nil {
+							rightName += `.` + result.GetName()
+						}
+						return cmpl.ErrorPos(expBuf.Pos, ErrStructAssign, rightName,
+							left.GetResult().GetName())
+					}
+					obj = getThreadFunc(cmpl, prior.Name, []*core.TypeObject{left.GetResult(),
+						right.GetResult()})
 				} else if left.GetResult() == right.GetResult() {
 					if left.GetResult().Original == reflect.TypeOf(core.Array{}) {
 						obj = cmpl.unit.FindObj(core.DefAssignArr)
@@ -266,6 +327,8 @@ func popBuf(cmpl *compiler) error {
 				} else if right.GetResult().Original == reflect.TypeOf(core.Array{}) &&
 					left.GetResult().IndexOf == right.GetResult().IndexOf {
 					obj = cmpl.unit.FindObj(core.DefAssignAddArr)
+				} else if isFnAssignable(left.GetResult().IndexOf, right.GetResult()) {
+					obj = cmpl.unit.FindObj(core.DefAssignAddFn)
 				}
 			}
 			if obj == nil {
@@ -273,6 +336,9 @@ func popBuf(cmpl *compiler) error {
 					left.GetResult(), right.GetResult()})
 			}
 		}
+		if goFunc, ok := right.(*core.CmdAnyFunc); ok && goFunc.IsThread {
+			goFunc.IsKept = true
+		}
 		icmd := &core.CmdBlock{ID: core.StackAssign, Object: obj,
 			Result: left.GetResult(), CmdCommon: core.CmdCommon{TokenID: uint32(expBuf.Pos)},
 			Children: []core.ICmd{left, right}}
@@ -459,6 +525,7 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 							curOpt   *optInfo
 							optCount int
 							opts     []int
+							isMethod bool
 						)
 						if len(cmpl.optionals) > 0 {
 							curOpt = cmpl.optionals[len(cmpl.optionals)-1]
@@ -472,6 +539,7 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 							cmpl.expbuf = cmpl.expbuf[:len(cmpl.expbuf)-1]
 							prevToken.LenExp--
 							cmpl.expbuf[len(cmpl.expbuf)-1] = prevToken
+							isMethod = true
 						}
 
 						numParams := len(cmpl.exp) - prevToken.LenExp - optCount
@@ -479,6 +547,8 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 						for i := 0; i < numParams; i++ {
 							params = append(params, cmpl.exp[prevToken.LenExp+i].GetResult())
 						}
+						enumPrint(cmpl, nameFunc, cmpl.exp[prevToken.LenExp:prevToken.LenExp+numParams],
+							params)
 						if nameFunc == `$` {
 							if len(cmpl.expbuf) == 1 && cmpl.curOwner().ID != core.StackReturn {
 								nameFunc = `Command`
@@ -523,17 +593,57 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 							}
 						} else {
 							var (
-								result *core.TypeObject
-								pobj   core.IObject
-								fnVar  core.ICmd
+								result    *core.TypeObject
+								genResult *core.TypeObject
+								pobj      core.IObject
+								fnVar     core.ICmd
+								obj       core.IObject
+								err       error
 							)
-							obj := getFunc(cmpl, nameFunc, params)
-							if obj == nil {
+							if isMethod {
+								obj = getMethod(cmpl, nameFunc, params)
+								if obj == nil && params[0] != nil && params[0].Iface != nil {
+									if fnVar, result, err = ifaceMethod(cmpl, prevToken.Pos-1,
+										nameFunc, params); err != nil {
+										return err
+									}
+								}
+							}
+							if obj == nil && fnVar == nil {
+								obj = getFunc(cmpl, nameFunc, params)
+							}
+							if obj == nil && fnVar == nil && optCount == 0 {
+								if icmd := enumFunc(cmpl, prevToken.Pos-1, nameFunc,
+									cmpl.exp[prevToken.LenExp:]); icmd != nil {
+									cmpl.exp = append(cmpl.exp[:prevToken.LenExp], icmd)
+									cmpl.expbuf = cmpl.expbuf[:len(cmpl.expbuf)-1]
+									return nil
+								}
+							}
+							if obj == nil && fnVar == nil {
+								obj = getIfaceFunc(cmpl, nameFunc,
+									cmpl.exp[prevToken.LenExp:prevToken.LenExp+numParams])
+							}
+							if obj == nil && fnVar == nil {
+								if obj, genResult, err = getGeneric(cmpl, nameFunc, params); err != nil {
+									return err
+								}
+							}
+							if obj == nil && fnVar == nil {
+								if obj, err = getFnFunc(cmpl, prevToken.Pos-1, nameFunc, params); err != nil {
+									return err
+								}
+							}
+							if obj == nil && fnVar == nil {
+								obj = getThreadFunc(cmpl, nameFunc, params)
+							}
+							if fnVar != nil {
+								// the method of the interface
+							} else if obj == nil {
 								var isMatch bool
-								block, ind := findVar(cmpl, nameFunc)
-								if block != nil {
-									fnVar = &core.CmdVar{Block: block, Index: ind,
-										CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos - 1)}}
+								if cmdVar := findVar(cmpl, nameFunc); cmdVar != nil {
+									cmdVar.TokenID = uint32(cmpl.pos - 1)
+									fnVar = cmdVar
 									if typeVar := fnVar.GetResult(); typeVar.Func != nil {
 										if len(typeVar.Func.Params) == len(params) {
 											isMatch = true
@@ -573,7 +683,9 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 								}
 							} else {
 								result = obj.Result()
-								if result != nil && len(params) > 0 {
+								if genResult != nil {
+									result = genResult
+								} else if result != nil && len(params) > 0 {
 									retName := result.GetName()
 									if retName == `arr*` || retName == `map*` {
 										result = params[0]
@@ -654,7 +766,7 @@ func setIndex(cmpl *compiler) error {
 	cmdVar := cmpl.exp[len(cmpl.exp)-2].(*core.CmdVar)
 	typeObject := cmdVar.GetResult()
 	varIndex := cmpl.getIntType()
-	if typeObject.Original == reflect.TypeOf(core.Map{}) {
+	if typeObject.Original == reflect.TypeOf(core.Map{}) && typeObject.KeyOf == nil {
 		varIndex = cmpl.getStrType()
 	}
 	if typeObject.IndexOf == nil {
@@ -669,6 +781,9 @@ func setIndex(cmpl *compiler) error {
 	if indexResult != varIndex {
 		return cmpl.ErrorPos(cmpl.pos, ErrTypeIndex, varIndex.GetName())
 	}
+	if typeObject.KeyOf != nil {
+		index = strKey(cmpl, index)
+	}
 	(*cmdVar).Indexes = append((*cmdVar).Indexes, core.CmdRet{Cmd: index, Type: typeObject.IndexOf})
 	cmpl.exp = cmpl.exp[:len(cmpl.exp)-1]
 	cmpl.expbuf = cmpl.expbuf[:len(cmpl.expbuf)-1]
@@ -738,6 +853,13 @@ func coComma(cmpl *compiler) error {
 	if isInState(cmpl, cmInit, 1) || isInState(cmpl, cmInit, 2) {
 		return nil
 	}
+	if len(cmpl.expbuf) == 0 && isReturn(cmpl) {
+		return nil
+	}
+	if len(cmpl.expbuf) == 0 && len(cmpl.exp) == 1 && cmpl.exp[0].GetType() == core.CtVar &&
+		(cmpl.curOwner().ID == core.StackBlock || cmpl.curOwner().ID == core.StackDefault) {
+		return coDestructAssign(cmpl)
+	}
 	if len(cmpl.expbuf) < 2 || (cmpl.expbuf[len(cmpl.expbuf)-1].Oper != tkLPar &&
 		cmpl.expbuf[len(cmpl.expbuf)-2].Oper != tkCallFunc) {
 		return cmpl.Error(ErrOper)
@@ -147,6 +147,38 @@ func getFunc(cmpl *compiler, name string, params []*core.TypeObject) (obj core.I
 	return nil
 }
 
+// getFnFunc looks for the embedded function with fn parameters. The fn parameter of an array
+// function must accept the item of the array
+func getFnFunc(cmpl *compiler, pos int, name string,
+	params []*core.TypeObject) (core.IObject, error) {
+	var (
+		isFn  bool
+		fnPar *core.TypeObject
+	)
+	pars := make([]*core.TypeObject, len(params))
+	for i, par := range params {
+		pars[i] = par
+		if par != nil && par.Func != nil {
+			isFn = true
+			fnPar = par
+			pars[i] = cmpl.unit.FindType(`fn`).(*core.TypeObject)
+		}
+	}
+	if !isFn {
+		return nil, nil
+	}
+	obj := getFunc(cmpl, name, pars)
+	if obj == nil {
+		return nil, nil
+	}
+	if params[0].Original == reflect.TypeOf(core.Array{}) && params[0].IndexOf != nil &&
+		(len(fnPar.Func.Params) != 1 || !isEqualTypes(fnPar.Func.Params[0], params[0].IndexOf)) {
+		return nil, cmpl.ErrorFunction(ErrFnCall, pos, fnPar.GetName(),
+			[]*core.TypeObject{params[0].IndexOf})
+	}
+	return obj, nil
+}
+
 func getOperator(cmpl *compiler, name string, left, right core.ICmd) (obj core.IObject) {
 	params := []*core.TypeObject{left.GetResult()}
 	if right != nil {
@@ -172,13 +204,40 @@ func coFuncBack(cmpl *compiler) error {
 }
 
 func coFuncName(cmpl *compiler) error {
-	token := getToken(cmpl.unit.Lexeme, cmpl.pos)
+	var receiver *core.TypeObject
+	lp := cmpl.unit.Lexeme
+	token := getToken(lp, cmpl.pos)
+	if len(lp.Tokens) > cmpl.pos+2 && lp.Tokens[cmpl.pos+1].Type == tkDot &&
+		lp.Tokens[cmpl.pos+2].Type == tkIdent && cmpl.unit.FindType(token) != nil {
+		token += `.` + getToken(lp, cmpl.pos+2)
+		cmpl.newPos = cmpl.pos + 2
+	}
+	if dot := strings.IndexRune(token, '.'); dot >= 0 {
+		if obj := cmpl.unit.FindType(token[:dot]); obj != nil {
+			receiver = obj.(*core.TypeObject)
+			if receiver.Custom == nil {
+				return cmpl.Error(ErrStructType, receiver.GetName())
+			}
+			token = token[dot+1:]
+		}
+	}
 	if isCapital(token) {
 		return cmpl.Error(ErrCapitalLetters)
 	}
 	if strings.IndexRune(token, '.') >= 0 {
 		return cmpl.Error(ErrIdent)
 	}
-	newFunc(cmpl, token)
-	return nil
+	if receiver == nil {
+		newFunc(cmpl, token)
+		if cmpl.binds != nil {
+			// skip type parameters of the generic instance
+			for cmpl.newPos = cmpl.pos; lp.Tokens[cmpl.newPos].Type != tkGreater; cmpl.newPos++ {
+			}
+			cmpl.latestFunc().Lex = lp
+		}
+		return nil
+	}
+	newFunc(cmpl, methodName(receiver, token))
+	cmpl.curType = receiver
+	return coVarToken(cmpl, receiverName)
 }
@@ -0,0 +1,376 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// extractGenerics moves generic functions out of the tokens of the unit. They are compiled
+// later for each set of the concrete types.
+func extractGenerics(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	tokens := lp.Tokens
+	out := make([]core.Token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Type != tkFunc || i+2 >= len(tokens) || tokens[i+1].Type != tkIdent ||
+			tokens[i+2].Type != tkLess {
+			out = append(out, tokens[i])
+			continue
+		}
+		pub := cmpl.unit.Pub == core.PubAll
+		last := len(out) - 1
+		for last >= 0 && out[last].Type == tkLine {
+			last--
+		}
+		if last >= 0 && out[last].Type == tkPub {
+			pub = true
+			out = append(out[:last], out[last+1:]...)
+		}
+		end, err := newGeneric(cmpl, i, pub)
+		if err != nil {
+			return err
+		}
+		i = end - 1
+	}
+	lp.Tokens = out
+	return nil
+}
+
+// newGeneric appends the generic function which starts from the func token and returns
+// the end of the function
+func newGeneric(cmpl *compiler, start int, pub bool) (int, error) {
+	var end int
+	lp := cmpl.unit.Lexeme
+	tokens := lp.Tokens
+	generic := &core.Generic{}
+	name := getToken(lp, start+1)
+	cmpl.pos = start + 1
+	if isCapital(name) {
+		return 0, cmpl.Error(ErrCapitalLetters)
+	}
+	if strings.IndexRune(name, '.') >= 0 {
+		return 0, cmpl.Error(ErrIdent)
+	}
+	i := start + 3
+	for ; i < len(tokens) && tokens[i].Type != tkGreater; i++ {
+		cmpl.pos = i
+		if (len(generic.Types) == 0 || tokens[i-1].Type == tkComma) && tokens[i].Type == tkIdent {
+			token := getToken(lp, i)
+			if strings.IndexRune(token, '.') >= 0 || cmpl.unit.FindType(token) != nil {
+				return 0, cmpl.Error(ErrGenericType)
+			}
+			generic.Types = append(generic.Types, token)
+		} else if tokens[i].Type != tkComma || tokens[i-1].Type != tkIdent {
+			return 0, cmpl.Error(ErrGenericType)
+		}
+	}
+	cmpl.pos = i
+	if i == len(tokens) || len(generic.Types) == 0 || tokens[i-1].Type != tkIdent {
+		return 0, cmpl.Error(ErrGenericType)
+	}
+	if i++; i < len(tokens) && tokens[i].Type == tkLPar {
+		var parType string
+		for i++; i < len(tokens) && tokens[i].Type != tkRPar; i++ {
+			cmpl.pos = i
+			switch tokens[i].Type {
+			case tkIdent:
+				if len(parType) == 0 {
+					parType = getToken(lp, i)
+				} else {
+					generic.Params = append(generic.Params, parType)
+				}
+			case tkComma:
+				parType = ``
+			case tkVariadic:
+				return 0, cmpl.Error(ErrGenericVar)
+			case tkLine:
+			default:
+				return 0, cmpl.Error(ErrName)
+			}
+		}
+		i++
+	}
+	for ; i < len(tokens) && tokens[i].Type == tkLine; i++ {
+	}
+	if i < len(tokens) && tokens[i].Type == tkIdent {
+		generic.Result = getToken(lp, i)
+		i++
+	}
+	for ; i < len(tokens) && tokens[i].Type == tkLine; i++ {
+	}
+	cmpl.pos = i
+	if i == len(tokens) {
+		return 0, cmpl.ErrorPos(i, ErrEnd)
+	}
+	switch tokens[i].Type {
+	case tkLCurly:
+		var level int
+		for end = i; end < len(tokens); end++ {
+			if tokens[end].Type == tkLCurly {
+				level++
+			} else if tokens[end].Type == tkRCurly {
+				if level--; level == 0 {
+					break
+				}
+			}
+		}
+		if end == len(tokens) {
+			return 0, cmpl.ErrorPos(end, ErrEnd)
+		}
+		end++
+	case tkColon:
+		for end = i; end < len(tokens); end++ {
+			if tokens[end].Type == tkLine && lp.Source[tokens[end].Offset] != ';' {
+				break
+			}
+		}
+	default:
+		return 0, cmpl.Error(ErrLCurly)
+	}
+	for _, item := range generic.Types {
+		var used bool
+		for _, par := range generic.Params {
+			if par == item || strings.HasSuffix(par, `.`+item) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return 0, cmpl.ErrorPos(start+1, ErrGenericUnused, item)
+		}
+	}
+	// generic functions in the source code can only overload embedded generic functions
+	if obj := cmpl.unit.FindGeneric(name); obj != nil && obj.GetType() == core.ObjFunc {
+		return 0, cmpl.ErrorPos(start+1, ErrFuncExists, name,
+			`(`+strings.Join(genericOf(obj).Params, `, `)+`)`)
+	}
+	generic.Lex = &core.Lex{
+		Source:  lp.Source,
+		Tokens:  append([]core.Token{}, tokens[start:end]...),
+		Lines:   lp.Lines,
+		Strings: lp.Strings,
+		Header:  lp.Header,
+		Path:    lp.Path,
+	}
+	funcObj := &core.FuncObject{
+		Object: core.Object{
+			Name: name,
+			Unit: cmpl.unit,
+			Pub:  pub,
+		},
+		Generic: generic,
+	}
+	cmpl.unit.VM.Objects = append(cmpl.unit.VM.Objects, funcObj)
+	cmpl.unit.AddGeneric(len(cmpl.ws.Objects)-1, funcObj, pub)
+	return end, nil
+}
+
+// genericOf returns the description of the generic function
+func genericOf(obj core.IObject) *core.Generic {
+	if obj.GetType() == core.ObjEmbedded {
+		return obj.(*core.EmbedObject).Generic
+	}
+	return obj.(*core.FuncObject).Generic
+}
+
+// bindType returns the type for the name with type parameters
+func bindType(cmpl *compiler, name string, binds map[string]*core.TypeObject) *core.TypeObject {
+	if typeObj, ok := binds[name]; ok {
+		return typeObj
+	}
+	if strings.HasPrefix(name, core.IntKeyMap) {
+		if indexOf := bindType(cmpl, name[len(core.IntKeyMap):], binds); indexOf != nil {
+			obj, _ := autoType(cmpl, core.IntKeyMap+indexOf.GetName())
+			return obj.(*core.TypeObject)
+		}
+		return nil
+	}
+	for prefix, original := range map[string]reflect.Type{`arr.`: reflect.TypeOf(core.Array{}),
+		`map.`: reflect.TypeOf(core.Map{})} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if indexOf := bindType(cmpl, name[len(prefix):], binds); indexOf != nil {
+			typeName := prefix + indexOf.GetName()
+			if obj := cmpl.unit.FindType(typeName); obj != nil {
+				return obj.(*core.TypeObject)
+			}
+			return cmpl.unit.NewType(typeName, original, indexOf).(*core.TypeObject)
+		}
+	}
+	return nil
+}
+
+// matchGeneric checks the type of the parameter and defines the type parameters
+func matchGeneric(cmpl *compiler, generic *core.Generic, name string, typeObj *core.TypeObject,
+	binds map[string]*core.TypeObject) bool {
+	if typeObj == nil {
+		return false
+	}
+	for _, item := range generic.Types {
+		if name != item {
+			continue
+		}
+		if bind, ok := binds[item]; ok {
+			return isEqualTypes(bind, typeObj)
+		}
+		binds[item] = typeObj
+		return true
+	}
+	if strings.HasPrefix(name, `arr.`) {
+		return typeObj.Original == reflect.TypeOf(core.Array{}) &&
+			matchGeneric(cmpl, generic, name[4:], typeObj.IndexOf, binds)
+	}
+	if strings.HasPrefix(name, `map.`) {
+		return typeObj.Original == reflect.TypeOf(core.Map{}) && typeObj.KeyOf == nil &&
+			matchGeneric(cmpl, generic, name[4:], typeObj.IndexOf, binds)
+	}
+	if strings.HasPrefix(name, core.IntKeyMap) {
+		return typeObj.Original == reflect.TypeOf(core.Map{}) && typeObj.KeyOf != nil &&
+			matchGeneric(cmpl, generic, name[len(core.IntKeyMap):], typeObj.IndexOf, binds)
+	}
+	if strings.HasPrefix(name, `thread.`) {
+		return isTypedThread(typeObj) &&
+			matchGeneric(cmpl, generic, name[7:], typeObj.IndexOf, binds)
+	}
+	if strings.HasPrefix(name, `fn.`) {
+		// fn.T.bool matches fn types with the parameter T and bool result
+		items := strings.Split(name[3:], `.`)
+		fnType := typeObj.Func
+		if fnType == nil || len(fnType.Params) != len(items)-1 {
+			return false
+		}
+		for i, par := range fnType.Params {
+			if !matchGeneric(cmpl, generic, items[i], par, binds) {
+				return false
+			}
+		}
+		return matchGeneric(cmpl, generic, items[len(items)-1], fnType.Result, binds)
+	}
+	obj, _ := autoType(cmpl, name)
+	return obj != nil && isEqualTypes(obj.(*core.TypeObject), typeObj)
+}
+
+// matchParams returns the types of the type parameters if the parameters match
+// the generic function
+func matchParams(cmpl *compiler, generic *core.Generic,
+	params []*core.TypeObject) map[string]*core.TypeObject {
+	if len(params) != len(generic.Params) {
+		return nil
+	}
+	binds := make(map[string]*core.TypeObject)
+	for i, par := range generic.Params {
+		if !matchGeneric(cmpl, generic, par, params[i], binds) {
+			return nil
+		}
+	}
+	return binds
+}
+
+// getGeneric returns the instance of the generic function for the parameters and the type
+// of its result
+func getGeneric(cmpl *compiler, name string, params []*core.TypeObject) (core.IObject,
+	*core.TypeObject, error) {
+	var (
+		generic *core.Generic
+		binds   map[string]*core.TypeObject
+	)
+	obj := cmpl.unit.FindGeneric(name)
+	for ; obj != nil; obj = generic.Next {
+		generic = genericOf(obj)
+		if binds = matchParams(cmpl, generic, params); binds != nil {
+			break
+		}
+	}
+	if obj == nil {
+		return nil, nil, nil
+	}
+	if obj.GetType() == core.ObjEmbedded {
+		if len(generic.Result) == 0 {
+			return obj, nil, nil
+		}
+		result := bindType(cmpl, generic.Result, binds)
+		if result == nil {
+			result = obj.Result()
+		}
+		return obj, result, nil
+	}
+	funcObj := obj.(*core.FuncObject)
+	pars := make([]*core.TypeObject, len(params))
+	for i, par := range generic.Params {
+		if pars[i] = bindType(cmpl, par, binds); pars[i] == nil {
+			pars[i] = params[i]
+		}
+	}
+	if inst, variadic := funcObj.Unit.FindFunc(name, pars); inst != nil && !variadic {
+		return inst, inst.Result(), nil
+	}
+	inst, err := instantiate(cmpl, funcObj, binds)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inst, inst.Result(), nil
+}
+
+// instantiate compiles the generic function for the concrete types
+func instantiate(cmpl *compiler, funcObj *core.FuncObject,
+	binds map[string]*core.TypeObject) (core.IObject, error) {
+	unit := funcObj.Unit
+	lex := *funcObj.Generic.Lex
+	lex.Tokens = append([]core.Token{}, lex.Tokens...)
+	lex.Strings = append([]string{}, lex.Strings...)
+	inst := &compiler{
+		ws:      cmpl.ws,
+		unit:    unit,
+		lexems:  []int{0},
+		runID:   core.Undefined,
+		owners:  make([]core.ICmd, 0, 128),
+		exp:     make([]core.ICmd, 0, 128),
+		expbuf:  make([]ExpBuf, 0, 128),
+		curIota: core.NotIota,
+		binds:   binds,
+	}
+	prevLex, prevPub := unit.Lexeme, unit.Pub
+	unit.Lexeme, unit.Pub = &lex, 0
+	defer func() {
+		unit.Lexeme, unit.Pub = prevLex, prevPub
+	}()
+	if err := inst.compile(); err != nil {
+		return nil, err
+	}
+	return cmpl.ws.Objects[inst.curFunc], nil
+}
+
+// genericTypes returns the actual types of the type parameters which are passed to
+// the embedded generic function as values. They follow the command in the bytecode
+func genericTypes(embed *core.EmbedObject, cmd core.ICmd, out *core.Bytecode) []core.Bcode {
+	anyFunc, ok := cmd.(*core.CmdAnyFunc)
+	if !ok {
+		return nil
+	}
+	isType := func(name string) bool {
+		for _, item := range embed.Generic.Types {
+			if item == name {
+				return true
+			}
+		}
+		return false
+	}
+	var ret []core.Bcode
+	for i, par := range embed.Generic.Params {
+		if isType(par) {
+			// we don't need call structOffset here because it doesn't matter type of struct
+			ret = append(ret, type2Code(anyFunc.Children[i].GetResult(), out))
+		}
+	}
+	if isType(embed.Generic.Result) {
+		ret = append(ret, type2Code(anyFunc.Result, out))
+	}
+	return ret
+}
@@ -5,6 +5,9 @@
 package compiler
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/gentee/gentee/core"
 )
 
@@ -16,8 +19,8 @@ type goStack struct {
 	Params     []core.ICmd
 }
 
-func goExpPush(cmpl *compiler) string {
-	name := `*` + core.RandName()
+func goExpPush(cmpl *compiler, prefix string) string {
+	name := prefix + core.RandName()
 	cmpl.goStack = append(cmpl.goStack, goStack{
 		Exp:        append(make([]core.ICmd, 0, len(cmpl.exp)), cmpl.exp...),
 		ExpBuf:     append(make([]ExpBuf, 0, len(cmpl.expbuf)), cmpl.expbuf...),
@@ -37,8 +40,62 @@ func goExpPop(cmpl *compiler) {
 	cmpl.goStack = cmpl.goStack[:len(cmpl.goStack)-1]
 }
 
+// isThreadBlock returns true if the block is the function of go statement
+func isThreadBlock(block *core.CmdBlock) bool {
+	if block.Object == nil || block.Object.GetType() != core.ObjFunc {
+		return false
+	}
+	name := block.Object.GetName()
+	return len(name) > 0 && name[0] == '*'
+}
+
+// isTypedThread returns true if the type is thread.T with the result type of the go block
+func isTypedThread(typeObj *core.TypeObject) bool {
+	return typeObj != nil && typeObj.IndexOf != nil &&
+		typeObj.Original == reflect.TypeOf(int64(0)) && strings.HasPrefix(typeObj.GetName(), `thread.`)
+}
+
+// goResult returns the result type of the block if cmd is the go expression
+func goResult(cmd core.ICmd) (*core.TypeObject, bool) {
+	goFunc, ok := cmd.(*core.CmdAnyFunc)
+	if !ok || !goFunc.IsThread {
+		return nil, false
+	}
+	return goFunc.Object.(*core.FuncObject).Block.Result, true
+}
+
+// isThreadAssignable returns true if the value can be assigned to the thread variable.
+// thread.T accepts the go expression with T result or the same thread.T value and thread
+// accepts any thread
+func isThreadAssignable(left *core.TypeObject, right core.ICmd) bool {
+	if left.GetName() == `thread` {
+		return isTypedThread(right.GetResult())
+	}
+	if result, ok := goResult(right); ok {
+		return isEqualTypes(left.IndexOf, result)
+	}
+	return left == right.GetResult()
+}
+
+// getThreadFunc looks for the function with thread parameters if there are thread.T parameters
+func getThreadFunc(cmpl *compiler, name string, params []*core.TypeObject) core.IObject {
+	var isTyped bool
+	pars := make([]*core.TypeObject, len(params))
+	for i, par := range params {
+		pars[i] = par
+		if isTypedThread(par) {
+			isTyped = true
+			pars[i] = cmpl.unit.FindType(`thread`).(*core.TypeObject)
+		}
+	}
+	if !isTyped {
+		return nil
+	}
+	return getFunc(cmpl, name, pars)
+}
+
 func coGo(cmpl *compiler) error {
-	newFunc(cmpl, goExpPush(cmpl))
+	newFunc(cmpl, goExpPush(cmpl, `*`))
 	return nil
 }
 
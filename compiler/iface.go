@@ -0,0 +1,232 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+func coIface(cmpl *compiler) error {
+	token, err := checkNewType(cmpl)
+	if err != nil {
+		return err
+	}
+	pType := cmpl.unit.NewType(token, reflect.TypeOf(core.Iface{}), nil).(*core.TypeObject)
+	pType.Iface = &core.IfaceType{}
+	cmpl.curType = pType
+	return nil
+}
+
+func coIfaceEnd(cmpl *compiler) error {
+	cmpl.curType = nil
+	return nil
+}
+
+// coIfaceMethod reads the method of the interface like name(type1, type2) result
+func coIfaceMethod(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	start := cmpl.pos
+	token := getToken(lp, start)
+	if isCapital(token) {
+		return cmpl.Error(ErrCapitalLetters)
+	}
+	if strings.IndexRune(token, '.') >= 0 {
+		return cmpl.Error(ErrIdent)
+	}
+	iface := cmpl.curType.Iface
+	for _, name := range iface.Methods {
+		if name == token {
+			return cmpl.Error(ErrIfaceMethod, token)
+		}
+	}
+	i := start + 1
+	if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkLPar {
+		return cmpl.ErrorPos(i, ErrLPar)
+	}
+	fnType := &core.FnType{}
+	for i++; i < len(lp.Tokens) && lp.Tokens[i].Type != tkRPar; i++ {
+		if lp.Tokens[i].Type != tkIdent {
+			return cmpl.ErrorPos(i, ErrType)
+		}
+		cmpl.pos = i
+		obj, err := getType(cmpl)
+		if err != nil {
+			return err
+		}
+		fnType.Params = append(fnType.Params, obj.(*core.TypeObject))
+		if i+1 < len(lp.Tokens) && lp.Tokens[i+1].Type == tkComma {
+			if i += 2; i >= len(lp.Tokens) || lp.Tokens[i].Type != tkIdent {
+				return cmpl.ErrorPos(i, ErrType)
+			}
+			i--
+		} else if i+1 >= len(lp.Tokens) || lp.Tokens[i+1].Type != tkRPar {
+			return cmpl.ErrorPos(i+1, ErrNotRPar)
+		}
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(i, ErrNotRPar)
+	}
+	if i+1 < len(lp.Tokens) && lp.Tokens[i+1].Type == tkIdent {
+		i++
+		cmpl.pos = i
+		obj, err := getType(cmpl)
+		if err != nil {
+			return err
+		}
+		fnType.Result = obj.(*core.TypeObject)
+	}
+	iface.Methods = append(iface.Methods, token)
+	iface.Funcs = append(iface.Funcs, fnType)
+	cmpl.pos = start
+	cmpl.newPos = i
+	return nil
+}
+
+// implements checks if the struct type has all methods of the interface. It returns the name
+// of the missing method
+func implements(cmpl *compiler, structType, ifaceType *core.TypeObject) (string, bool) {
+	iface := ifaceType.Iface
+	if structType.Custom == nil || structType.IsTuple() {
+		return ``, false
+	}
+	if _, ok := iface.Impls[structType]; ok {
+		return ``, true
+	}
+	methods := make([]core.IObject, len(iface.Methods))
+	for i, name := range iface.Methods {
+		params := append([]*core.TypeObject{structType}, iface.Funcs[i].Params...)
+		obj := getFunc(cmpl, methodName(structType, name), params)
+		if obj == nil || !isEqualTypes(obj.Result(), iface.Funcs[i].Result) ||
+			obj.GetType() != core.ObjFunc {
+			return name, false
+		}
+		methods[i] = obj
+	}
+	if iface.Impls == nil {
+		iface.Impls = make(map[*core.TypeObject][]core.IObject)
+	}
+	iface.Impls[structType] = methods
+	return ``, true
+}
+
+// toIface converts the struct value to the interface if the struct type implements it
+func toIface(cmpl *compiler, ifaceType *core.TypeObject, icmd core.ICmd) core.ICmd {
+	if ifaceType == nil || ifaceType.Iface == nil {
+		return icmd
+	}
+	if _, ok := implements(cmpl, icmd.GetResult(), ifaceType); !ok {
+		return icmd
+	}
+	return &core.CmdBlock{ID: core.StackIface, Result: ifaceType, Children: []core.ICmd{icmd},
+		CmdCommon: core.CmdCommon{TokenID: uint32(icmd.GetToken())}}
+}
+
+// isTypeofCase returns true if the expression is the operand of case in switch typeof(...)
+func isTypeofCase(cmpl *compiler) bool {
+	if !isCase(cmpl) {
+		return false
+	}
+	parent := cmpl.curOwner().Parent
+	if parent == nil || len(parent.Children) == 0 {
+		return false
+	}
+	call, ok := parent.Children[0].(*core.CmdAnyFunc)
+	return ok && call.Object != nil && call.Object.GetName() == `typeof`
+}
+
+// getIfaceFunc looks for the function which has interface parameters instead of the struct
+// values. It converts the struct values to the interfaces if the function has been found
+func getIfaceFunc(cmpl *compiler, name string, args []core.ICmd) core.IObject {
+	var ifaces []*core.TypeObject
+	params := make([]*core.TypeObject, len(args))
+	variants := make([][]*core.TypeObject, len(args))
+	for i, arg := range args {
+		params[i] = arg.GetResult()
+		if params[i] == nil || params[i].Custom == nil || params[i].IsTuple() {
+			continue
+		}
+		if ifaces == nil {
+			ifaces = cmpl.unit.Ifaces()
+		}
+		for _, iface := range ifaces {
+			if _, ok := implements(cmpl, params[i], iface); ok {
+				variants[i] = append(variants[i], iface)
+			}
+		}
+	}
+	var find func(int) core.IObject
+	find = func(ind int) core.IObject {
+		if ind == len(args) {
+			return getFunc(cmpl, name, params)
+		}
+		if obj := find(ind + 1); obj != nil {
+			return obj
+		}
+		origin := params[ind]
+		for _, iface := range variants[ind] {
+			params[ind] = iface
+			if obj := find(ind + 1); obj != nil {
+				return obj
+			}
+		}
+		params[ind] = origin
+		return nil
+	}
+	if len(ifaces) == 0 {
+		return nil
+	}
+	obj := find(0)
+	if obj != nil {
+		for i, arg := range args {
+			if params[i] != arg.GetResult() {
+				args[i] = toIface(cmpl, params[i], arg)
+			}
+		}
+	}
+	return obj
+}
+
+// methodKey returns the key of the interface method like name(type1,type2)result. The methods
+// with the same name and different parameters have different keys in the method table
+func methodKey(name string, fnType *core.FnType) string {
+	pars := make([]string, len(fnType.Params))
+	for i, par := range fnType.Params {
+		pars[i] = par.GetName()
+	}
+	key := name + `(` + strings.Join(pars, `,`) + `)`
+	if fnType.Result != nil {
+		key += fnType.Result.GetName()
+	}
+	return key
+}
+
+// ifaceMethod returns the command which gets the method of the interface value
+func ifaceMethod(cmpl *compiler, pos int, name string,
+	params []*core.TypeObject) (core.ICmd, *core.TypeObject, error) {
+	iface := params[0].Iface
+	for i, method := range iface.Methods {
+		if method != name {
+			continue
+		}
+		fnType := iface.Funcs[i]
+		isMatch := len(fnType.Params) == len(params)-1
+		for j := 0; isMatch && j < len(fnType.Params); j++ {
+			isMatch = isEqualTypes(fnType.Params[j], params[j+1])
+		}
+		if !isMatch {
+			return nil, nil, cmpl.ErrorFunction(ErrFnCall, pos, params[0].GetName()+`.`+name,
+				params[1:])
+		}
+		return &core.CmdBlock{ID: core.StackMethod, Result: params[0],
+			CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+			Children: []core.ICmd{&core.CmdValue{Value: methodKey(name, fnType),
+				Result:    cmpl.getStrType(),
+				CmdCommon: core.CmdCommon{TokenID: uint32(pos)}}}}, fnType.Result, nil
+	}
+	return nil, nil, cmpl.ErrorPos(pos, ErrIfaceCall, name, params[0].GetName())
+}
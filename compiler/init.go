@@ -43,7 +43,8 @@ func coInitEnd(cmpl *compiler) error {
 	for _, item := range cmpl.curOwner().Children {
 		switch ownerType.Original {
 		case reflect.TypeOf(core.Array{}):
-			if !isEqualTypes(item.GetResult(), cmpl.curType) {
+			if !isEqualTypes(item.GetResult(), cmpl.curType) &&
+				!isFnAssignable(cmpl.curType, item.GetResult()) {
 				return cmpl.ErrorPos(item.GetToken(), ErrWrongType, cmpl.curType.GetName())
 			}
 		case reflect.TypeOf(core.Set{}):
@@ -63,7 +64,8 @@ func coInitEnd(cmpl *compiler) error {
 				return cmpl.ErrorPos(item.GetToken(), ErrValue)
 			}
 			if ownerType.Original == reflect.TypeOf(core.Map{}) {
-				if !isEqualTypes(ownerType.IndexOf, item.(*core.CmdBinary).Right.GetResult()) {
+				right := item.(*core.CmdBinary).Right.GetResult()
+				if !isEqualTypes(ownerType.IndexOf, right) && !isFnAssignable(ownerType.IndexOf, right) {
 					return cmpl.ErrorPos(item.(*core.CmdBinary).Right.GetToken(),
 						ErrWrongType, ownerType.IndexOf.GetName())
 				}
@@ -132,7 +134,12 @@ func initMapEnd(cmpl *compiler) error {
 			}
 			continue
 		}
-		if item.GetResult().Original != reflect.TypeOf(``) {
+		if keyOf := block.GetResult().KeyOf; keyOf != nil {
+			if item.GetResult() != keyOf {
+				return cmpl.ErrorPos(item.GetToken(), ErrWrongType, keyOf.GetName())
+			}
+			item = strKey(cmpl, item)
+		} else if item.GetResult().Original != reflect.TypeOf(``) {
 			return cmpl.ErrorPos(item.GetToken(), ErrWrongType, `str`)
 		}
 		cmd := &core.CmdBinary{CmdCommon: core.CmdCommon{TokenID: uint32(item.GetToken())},
@@ -145,6 +152,13 @@ func initMapEnd(cmpl *compiler) error {
 	return nil
 }
 
+// strKey converts the int key of the map to the string key which is stored in the map
+func strKey(cmpl *compiler, key core.ICmd) core.ICmd {
+	obj := getFunc(cmpl, `str`, []*core.TypeObject{key.GetResult()})
+	return &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: uint32(key.GetToken())},
+		Object: obj, Result: obj.Result(), Children: []core.ICmd{key}}
+}
+
 func initStructEnd(cmpl *compiler) error {
 	var (
 		fieldName string
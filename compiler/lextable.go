@@ -68,34 +68,39 @@ const (
 
 var (
 	keywords = map[string]int{
-		`break`:    tkBreak,
-		`continue`: tkContinue,
-		`elif`:     tkElif,
-		`else`:     tkElse,
-		`false`:    tkFalse,
-		`for`:      tkFor,
-		`func`:     tkFunc,
-		`if`:       tkIf,
-		`in`:       tkIn,
-		`while`:    tkWhile,
-		`return`:   tkReturn,
-		`run`:      tkRun,
-		`true`:     tkTrue,
-		`const`:    tkConst,
-		`struct`:   tkStruct,
-		`switch`:   tkSwitch,
-		`case`:     tkCase,
-		`include`:  tkInclude,
-		`import`:   tkImport,
-		`pub`:      tkPub,
-		`fn`:       tkFn,
-		`go`:       tkGo,
-		`local`:    tkLocal,
-		`try`:      tkTry,
-		`catch`:    tkCatch,
-		`recover`:  tkRecover,
-		`retry`:    tkRetry,
-		`default`:  tkDefault,
+		`break`:     tkBreak,
+		`continue`:  tkContinue,
+		`elif`:      tkElif,
+		`else`:      tkElse,
+		`false`:     tkFalse,
+		`for`:       tkFor,
+		`func`:      tkFunc,
+		`if`:        tkIf,
+		`in`:        tkIn,
+		`while`:     tkWhile,
+		`return`:    tkReturn,
+		`run`:       tkRun,
+		`true`:      tkTrue,
+		`const`:     tkConst,
+		`struct`:    tkStruct,
+		`switch`:    tkSwitch,
+		`case`:      tkCase,
+		`include`:   tkInclude,
+		`import`:    tkImport,
+		`pub`:       tkPub,
+		`fn`:        tkFn,
+		`go`:        tkGo,
+		`local`:     tkLocal,
+		`try`:       tkTry,
+		`catch`:     tkCatch,
+		`recover`:   tkRecover,
+		`retry`:     tkRetry,
+		`default`:   tkDefault,
+		`timeout`:   tkTimeout,
+		`defer`:     tkDefer,
+		`finally`:   tkFinally,
+		`interface`: tkInterface,
+		`enum`:      tkEnum,
 	}
 
 	charType [alphabet]int
@@ -108,7 +113,8 @@ var (
 			{nil, lexError | ErrLetter, nil},
 			{'S', 0, nil},
 			{[]rune{'\n', ';'}, 0, newLine},
-			{[]rune{'{', '(', ')', '[', ']', ',', '?', '~'}, 0, newSymbol},
+			{[]rune{'{', '(', ')', '[', ']', ',', '~'}, 0, newSymbol},
+			{[]string{`??`, `?.`, `?`}, 0, newOper},
 			{[]string{`+=`, `++`, `+`}, 0, newOper},
 			{[]string{`-=`, `--`, `-`}, 0, newOper},
 			{[]string{`*=`, `*`}, 0, newOper},
@@ -332,7 +338,30 @@ func newIdent(lex *lexEngine, start, off int) {
 			tokType = keyType
 		}
 		lex.Lex.NewToken(tokType, start, lex.Off-start)
+		if tokType == tkIdent {
+			joinMapType(lex.Lex)
+		}
+	}
+}
+
+// joinMapType joins the tokens of map[key].type into one identifier
+func joinMapType(lp *core.Lex) {
+	count := len(lp.Tokens)
+	if count < 6 {
+		return
+	}
+	tokens := lp.Tokens[count-6:]
+	for i, tk := range []int{tkIdent, tkLSBracket, tkIdent, tkRSBracket, tkDot, tkIdent} {
+		if tokens[i].Type != int32(tk) ||
+			(i > 0 && tokens[i].Offset != tokens[i-1].Offset+tokens[i-1].Length) {
+			return
+		}
+	}
+	if name := getToken(lp, count-6); name != `map` && !strings.HasSuffix(name, `.map`) {
+		return
 	}
+	tokens[0].Length = tokens[5].Offset + tokens[5].Length - tokens[0].Offset
+	lp.Tokens = lp.Tokens[:count-5]
 }
 
 func newLine(lex *lexEngine, start, off int) {
@@ -25,7 +25,7 @@ func isBaseResult(cmd core.ICmd) bool {
 	case `int`, `bool`, `float`, `char`, `str`:
 		return true
 	}
-	return false
+	return cmd.GetResult().Enum != nil
 }
 
 func isCase(cmpl *compiler) bool {
@@ -33,6 +33,12 @@ func isCase(cmpl *compiler) bool {
 	return parent.GetType() == core.CtStack && parent.(*core.CmdBlock).ID == core.StackCase
 }
 
+func isReturn(cmpl *compiler) bool {
+	parent := cmpl.owners[len(cmpl.owners)-1]
+	return parent.GetType() == core.CtStack && (parent.(*core.CmdBlock).ID == core.StackReturn ||
+		parent.(*core.CmdBlock).ID == core.StackLocret)
+}
+
 func isIndexResult(cmd core.ICmd) bool {
 	return cmd.GetResult().IndexOf != nil
 }
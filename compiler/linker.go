@@ -94,6 +94,7 @@ func Link(ws *core.Workspace, unitID int) (*core.Exec, error) {
 				exec.Structs = append(exec.Structs, usedCode.StructsList[curInd])
 			}
 			retype[curInd] = ind
+			mergeMethods(&exec.Structs[ind], usedCode.StructsList[curInd].Methods)
 		}
 		for ; countStructs < len(exec.Structs); countStructs++ {
 			sinfo := &exec.Structs[countStructs]
@@ -156,6 +157,49 @@ func copyUsed(src, dest *core.Bytecode) {
 	}
 }
 
+// useFunc appends the function to the used objects of the bytecode
+func useFunc(fnObj *core.FuncObject, out *core.Bytecode) {
+	id := fnObj.ObjID
+	if out.Used == nil {
+		out.Used = make(map[int32]byte)
+	}
+	if out.Used[id] == 0 {
+		genBytecode(fnObj.Unit.VM, id)
+		copyUsed(&fnObj.BCode, out)
+		out.Used[id] = 1
+	}
+}
+
+// mergeMethods appends the methods of the struct type from the bytecode of the used function
+func mergeMethods(sInfo *core.StructInfo, methods map[string]int32) {
+	if len(methods) == 0 {
+		return
+	}
+	merged := make(map[string]int32, len(sInfo.Methods)+len(methods))
+	for name, id := range sInfo.Methods {
+		merged[name] = id
+	}
+	for name, id := range methods {
+		merged[name] = id
+	}
+	sInfo.Methods = merged
+}
+
+// ifaceCode appends the methods of the interface to the methods of the struct type. They are
+// used for calling the methods of interface values
+func ifaceCode(structType, ifaceType *core.TypeObject, out *core.Bytecode) {
+	sInfo := &out.StructsList[(type2Code(structType, out)-core.TYPESTRUCT)>>8]
+	if sInfo.Methods == nil {
+		sInfo.Methods = make(map[string]int32)
+	}
+	iface := ifaceType.Iface
+	for i, method := range iface.Impls[structType] {
+		fnObj := method.(*core.FuncObject)
+		sInfo.Methods[methodKey(iface.Methods[i], iface.Funcs[i])] = fnObj.ObjID
+		useFunc(fnObj, out)
+	}
+}
+
 func structOffset(out *core.Bytecode, shift int) {
 	out.StructsOffset = append(out.StructsOffset, int32(shift))
 }
@@ -224,9 +268,34 @@ func initBlock(linker *Linker, cmd *core.CmdBlock, out *core.Bytecode) (BlockInf
 		push(types...)
 	}
 	linker.Blocks = append(linker.Blocks, bInfo)
+	for i := range cmd.Vars {
+		if _, ok := cmd.Captured[i]; ok {
+			initBox(bInfo, i, out)
+		}
+	}
 	return bInfo, types
 }
 
+// initBox moves the value of the variable captured by closures to its box
+func initBox(bInfo BlockInfo, ind int, out *core.Bytecode) {
+	push := func(pars ...core.Bcode) {
+		out.Code = append(out.Code, pars...)
+	}
+	box := bInfo.Block.Captured[ind].Index
+	varType := type2Code(bInfo.Block.Vars[ind], out)
+	boxType := type2Code(bInfo.Block.Vars[box], out)
+	push(core.GETVAR, varType<<16|core.Bcode(bInfo.Vars[ind]))
+	if varType >= core.TYPESTRUCT {
+		structOffset(out, -len(out.Code)+1)
+	}
+	push(core.Bcode(1<<16|core.INITOBJ), varType<<16|boxType)
+	if varType >= core.TYPESTRUCT {
+		structOffset(out, -len(out.Code)+1)
+	}
+	push(core.SETVAR, boxType<<16|core.Bcode(bInfo.Vars[box]), boxType<<16|core.ASSIGNPTR,
+		boxType<<16|core.POP)
+}
+
 func type2Code(itype *core.TypeObject, out *core.Bytecode) (retType core.Bcode) {
 	switch itype.Original {
 	case reflect.TypeOf(int64(0)):
@@ -255,6 +324,8 @@ func type2Code(itype *core.TypeObject, out *core.Bytecode) (retType core.Bcode)
 		retType = core.TYPESET
 	case reflect.TypeOf(core.Obj{}):
 		retType = core.TYPEOBJ
+	case reflect.TypeOf(core.Iface{}):
+		retType = core.TYPEIFACE
 	case reflect.TypeOf(core.Struct{}):
 		typeName := itype.GetName()
 		var (
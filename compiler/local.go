@@ -11,10 +11,11 @@ import (
 )
 
 func getLocalBlock(cmpl *compiler) *core.CmdBlock {
-	for i := len(cmpl.owners) - 1; i >= 0; i-- {
-		block := cmpl.owners[i].(*core.CmdBlock)
+	owners := funcOwners(cmpl)
+	for i := len(owners) - 1; i >= 0; i-- {
+		block := owners[i].(*core.CmdBlock)
 		if block.ID == core.StackBlock && block.Parent != nil && block.Parent.ID == core.StackLocal {
-			return cmpl.owners[i].(*core.CmdBlock)
+			return block
 		}
 	}
 	return nil
@@ -88,7 +89,7 @@ func coLocalStart(cmpl *compiler) error {
 }
 
 func getLocal(cmpl *compiler, name string, params []*core.TypeObject) (cmd core.ICmd) {
-	for _, owner := range cmpl.owners {
+	for _, owner := range funcOwners(cmpl) {
 		block := owner.(*core.CmdBlock)
 		if ind, ok := block.LocalNames[name]; ok {
 			local := block.Locals[ind].(*core.CmdBlock)
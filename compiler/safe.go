@@ -0,0 +1,158 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+func coSafeDot(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if len(cmpl.exp) == 0 {
+		return cmpl.Error(ErrValue)
+	}
+	if len(lp.Tokens) == cmpl.pos+1 || lp.Tokens[cmpl.pos+1].Type != tkIdent {
+		return cmpl.Error(ErrName)
+	}
+	cmpl.pos++
+	left := cmpl.exp[len(cmpl.exp)-1]
+	for _, field := range strings.Split(getToken(lp, cmpl.pos), `.`) {
+		icmd, err := safeField(cmpl, left, field)
+		if err != nil {
+			return err
+		}
+		left = icmd
+	}
+	cmpl.exp[len(cmpl.exp)-1] = left
+	cmpl.newPos = cmpl.pos
+	return nil
+}
+
+// safeField appends the access to the field to the chain of ?. operators. The chain is
+// compiled into ?(condition, value, default value)
+func safeField(cmpl *compiler, left core.ICmd, field string) (core.ICmd, error) {
+	var (
+		cond  core.ICmd
+		value core.ICmd
+		safe  *core.CmdBlock
+	)
+	value = left
+	if left.GetType() == core.CtStack && left.(*core.CmdBlock).ID == core.StackSafe {
+		safe = left.(*core.CmdBlock)
+		cond = safe.Children[0]
+		value = safe.Children[1]
+	}
+	token := uint32(cmpl.pos)
+	fieldValue := &core.CmdValue{Value: field, CmdCommon: core.CmdCommon{TokenID: token},
+		Result: cmpl.getStrType()}
+	vType := value.GetResult()
+	switch {
+	case vType.GetName() == `obj`:
+		isMap := getFunc(cmpl, `IsMap`, []*core.TypeObject{vType})
+		cond = safeAnd(cond, &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: token},
+			Object: isMap, Result: isMap.Result(), Children: []core.ICmd{value}})
+		obj := getFunc(cmpl, `item`, []*core.TypeObject{vType, cmpl.getStrType()})
+		value = &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: token},
+			Object: obj, Result: obj.Result(), Children: []core.ICmd{value, fieldValue}}
+	case vType.Custom != nil && value.GetType() == core.CtVar:
+		indField, typeField, err := structIndex(cmpl, vType, field)
+		if err != nil {
+			return nil, err
+		}
+		cmdVar := *value.(*core.CmdVar)
+		cmdVar.Indexes = append(append([]core.CmdRet{}, cmdVar.Indexes...), core.CmdRet{
+			Cmd: &core.CmdValue{Value: indField, CmdCommon: core.CmdCommon{TokenID: token},
+				Result: cmpl.getIntType()}, Type: typeField})
+		value = &cmdVar
+	case strings.HasPrefix(vType.GetName(), `map.`) && vType.KeyOf == nil &&
+		value.GetType() == core.CtVar:
+		params := []*core.TypeObject{vType, cmpl.getStrType()}
+		obj, _, err := getGeneric(cmpl, `IsKey`, params)
+		if err != nil {
+			return nil, err
+		}
+		cond = safeAnd(cond, &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: token},
+			Object: obj, Result: obj.Result(), Children: []core.ICmd{value, fieldValue}})
+		cmdVar := *value.(*core.CmdVar)
+		cmdVar.Indexes = append(append([]core.CmdRet{}, cmdVar.Indexes...), core.CmdRet{
+			Cmd: fieldValue, Type: vType.IndexOf})
+		value = &cmdVar
+	default:
+		return nil, cmpl.Error(ErrSafeDot, vType.GetName())
+	}
+	if cond == nil {
+		return value, nil
+	}
+	if safe == nil {
+		safe = &core.CmdBlock{ID: uint32(core.StackSafe), CmdCommon: core.CmdCommon{TokenID: token},
+			Children: []core.ICmd{cond, value, nil}}
+	}
+	safe.Children[0] = cond
+	safe.Children[1] = value
+	if safe.Result != value.GetResult() {
+		safe.Result = value.GetResult()
+		safe.Children[2] = zeroValue(cmpl, safe.Result)
+	}
+	return safe, nil
+}
+
+// safeAnd appends the check to the condition of the chain of ?. operators
+func safeAnd(cond core.ICmd, check *core.CmdAnyFunc) core.ICmd {
+	if cond == nil {
+		return check
+	}
+	return &core.CmdBlock{ID: uint32(core.StackAnd), Result: check.Result,
+		CmdCommon: core.CmdCommon{TokenID: check.TokenID}, Children: []core.ICmd{cond, check}}
+}
+
+// zeroValue returns a hidden variable which has the default value of the type
+func zeroValue(cmpl *compiler, typeObj *core.TypeObject) core.ICmd {
+	block := cmpl.curOwner()
+	for block.ID != core.StackBlock && block.ID != core.StackDefault {
+		block = block.Parent
+	}
+	block.Vars = append(block.Vars, typeObj)
+	return &core.CmdVar{Block: block, Index: len(block.Vars) - 1}
+}
+
+// coalesce returns the command for the operator left ?? right
+func coalesce(cmpl *compiler, left, right core.ICmd, pos int) (core.ICmd, error) {
+	var safe *core.CmdBlock
+	value := left
+	if left.GetType() == core.CtStack && left.(*core.CmdBlock).ID == core.StackSafe {
+		safe = left.(*core.CmdBlock)
+		value = safe.Children[1]
+	}
+	lType, rType := value.GetResult(), right.GetResult()
+	switch {
+	case safe != nil && isEqualTypes(lType, rType) && lType.GetName() != `obj`:
+		safe.Children[2] = right
+		return safe, nil
+	case lType.GetName() == `obj` && rType.GetName() == `obj`:
+		isNil := getFunc(cmpl, `IsNil`, []*core.TypeObject{lType})
+		return &core.CmdBlock{ID: uint32(core.StackQuestion), Result: lType,
+			CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+			Children: []core.ICmd{&core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+				Object: isNil, Result: isNil.Result(), Children: []core.ICmd{left}},
+				right, left}}, nil
+	case lType.GetName() == `obj`:
+		obj := getFunc(cmpl, rType.GetName(), []*core.TypeObject{lType, rType})
+		if obj == nil {
+			break
+		}
+		value = &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+			Object: obj, Result: obj.Result(), Children: []core.ICmd{value, right}}
+		if safe == nil {
+			return value, nil
+		}
+		safe.Result = rType
+		safe.Children[1] = value
+		safe.Children[2] = right
+		return safe, nil
+	}
+	return nil, cmpl.ErrorPos(pos, ErrCoalesce, lType.GetName(), rType.GetName())
+}
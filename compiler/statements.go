@@ -9,6 +9,9 @@ import (
 )
 
 func coReturn(cmpl *compiler) error {
+	if isInDefer(cmpl) {
+		return cmpl.Error(ErrDeferReturn)
+	}
 	coExpStart(cmpl)
 	id := uint32(core.StackReturn)
 	if local := getLocalBlock(cmpl); local != nil {
@@ -27,6 +30,10 @@ func coReturnBack(cmpl *compiler) error {
 		block = &cmpl.latestFunc().Block
 	}
 
+	if len(owner.Children) > 1 {
+		// several values are returned as a tuple
+		owner.Children = []core.ICmd{newTuple(cmpl, owner.Children)}
+	}
 	switch len(owner.Children) {
 	case 0:
 		if block.Result != nil {
@@ -34,8 +41,13 @@ func coReturnBack(cmpl *compiler) error {
 		}
 	case 1:
 		if block.Result == nil {
-			return cmpl.Error(ErrReturn)
+			if !isThreadBlock(block) {
+				return cmpl.Error(ErrReturn)
+			}
+			// the first return defines the result type of the thread
+			block.Result = owner.Children[0].GetResult()
 		}
+		owner.Children[0] = toIface(cmpl, block.Result, owner.Children[0])
 		if !isEqualTypes(block.Result, owner.Children[0].GetResult()) {
 			return cmpl.Error(ErrReturnType)
 		}
@@ -148,6 +160,34 @@ func coWhileBack(cmpl *compiler) error {
 	return nil
 }
 
+func coTimeout(cmpl *compiler) error {
+	coExpStart(cmpl)
+	cmd := core.CmdBlock{ID: core.StackTimeout, CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	appendCmd(cmpl, &cmd)
+	cmpl.owners = append(cmpl.owners, &cmd)
+	return nil
+}
+
+func coTimeoutBack(cmpl *compiler) error {
+	cmd := cmpl.curOwner()
+	if cmd.ID == core.StackTimeout {
+		if len(cmd.Children) == 1 {
+			if cmd.Children[0].GetResult() != cmpl.getIntType() {
+				cmpl.pos = cmd.Children[0].GetToken()
+				return cmpl.Error(ErrWrongType, `int`)
+			}
+			cmdBlock := core.CmdBlock{ID: core.StackBlock, Parent: cmd,
+				CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+			cmd.Children = append(cmd.Children, &cmdBlock)
+			cmpl.owners = append(cmpl.owners, &cmdBlock)
+			cmpl.dynamic = &cmState{tkLCurly, cmLCurly, nil, nil, 0}
+		}
+	} else {
+		cmpl.owners = cmpl.owners[:len(cmpl.owners)-2]
+	}
+	return nil
+}
+
 func coFor(cmpl *compiler) error {
 	coExpStart(cmpl)
 	cmd := core.CmdBlock{ID: core.StackFor, CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
@@ -226,6 +266,9 @@ func coSwitchBack(cmpl *compiler) error {
 			}
 			cmpl.dynamic = &cmState{tkCase, cmCaseMust, nil, nil, 0}
 		} else {
+			if err := enumSwitch(cmpl, cmd); err != nil {
+				return err
+			}
 			cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 		}
 	}
@@ -44,6 +44,15 @@ func InitStdlib(ws *core.Workspace) {
 	stdlib.NewConst(core.ConstOnlyFiles, int64(vm.OnlyFiles), false)
 	stdlib.NewConst(core.ConstRegExp, int64(vm.RegExp), false)
 
+	// For the result of Status(thread)
+	stdlib.NewConst(core.ConstThQueue, int64(vm.ThQueue), false)
+	stdlib.NewConst(core.ConstThWork, int64(vm.ThWork), false)
+	stdlib.NewConst(core.ConstThPaused, int64(vm.ThPaused), false)
+	stdlib.NewConst(core.ConstThWait, int64(vm.ThWait), false)
+	stdlib.NewConst(core.ConstThFinished, int64(vm.ThFinished), false)
+	stdlib.NewConst(core.ConstThError, int64(vm.ThError), false)
+	stdlib.NewConst(core.ConstThClosed, int64(vm.ThClosed), false)
+
 	src := `
 	pub	func Run(str cmd, str args...) {
 		buf ? stdin &= sysBufNil()
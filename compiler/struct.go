@@ -92,3 +92,19 @@ func structIndex(cmpl *compiler, typeObj *core.TypeObject, field string) (int64,
 	}
 	return indField, typeObj.Custom.Types[indField], nil
 }
+
+// receiverName is the name of the implicit parameter of methods
+const receiverName = `this`
+
+// methodName returns the name of the function object of the method
+func methodName(typeObj *core.TypeObject, name string) string {
+	return typeObj.GetName() + `.` + name
+}
+
+// getMethod looks for the method of the struct type which is the first parameter
+func getMethod(cmpl *compiler, name string, params []*core.TypeObject) core.IObject {
+	if len(params) == 0 || params[0] == nil || params[0].Custom == nil {
+		return nil
+	}
+	return getFunc(cmpl, methodName(params[0], name), params)
+}
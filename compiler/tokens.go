@@ -69,11 +69,13 @@ const (
 	tkCtx                      // #
 	tkDoubleCtx                // ##
 	tkCtxEq                    // #=
+	tkSafeDot                  // ?.
+	tkCoalesce                 // ??
 )
 
 // Keywords
 const (
-	tkRun = iota + 64 // run
+	tkRun = iota + 80 // run
 	tkReturn
 	tkFalse
 	tkFor
@@ -101,6 +103,11 @@ const (
 	tkRecover
 	tkRetry
 	tkDefault
+	tkTimeout
+	tkDefer
+	tkFinally
+	tkInterface
+	tkEnum
 	tkToken // is used for preCompileTable
 )
 
@@ -164,5 +171,7 @@ var (
 		`#`:   tkCtx,
 		`##`:  tkDoubleCtx,
 		`#=`:  tkCtxEq,
+		`?.`:  tkSafeDot,
+		`??`:  tkCoalesce,
 	}
 )
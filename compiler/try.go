@@ -20,14 +20,19 @@ func coTry(cmpl *compiler) error {
 }
 
 func coTryBack(cmpl *compiler) error {
+	if cmpl.curOwner().ID == core.StackTry { // there is no finally
+		cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+		return nil
+	}
 	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 	cmd := cmpl.curOwner()
-	if cmd.ID == core.StackTry {
-		if len(cmd.Children) == 1 {
-			cmpl.dynamic = &cmState{tkLCurly, cmCatch, nil, nil, 0}
-		} else {
-			cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
-		}
+	switch len(cmd.Children) {
+	case 1:
+		cmpl.dynamic = &cmState{tkLCurly, cmCatch, nil, nil, 0}
+	case 2:
+		cmpl.dynamic = &cmState{tkLCurly, cmFinally, nil, nil, 0}
+	default:
+		cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 	}
 	return nil
 }
@@ -45,13 +50,20 @@ func coCatch(cmpl *compiler) error {
 	return nil
 }
 
-func coCatchBack(cmpl *compiler) error {
-	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+func coFinally(cmpl *compiler) error {
+	cmd := cmpl.curOwner()
+	if len(cmd.Children) == 1 { // try without catch
+		cmd.Children = append(cmd.Children, nil)
+	}
+	cmdFinally := core.CmdBlock{ID: core.StackBlock, Parent: cmd,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	cmd.Children = append(cmd.Children, &cmdFinally)
+	cmpl.owners = append(cmpl.owners, &cmdFinally)
 	return nil
 }
 
 func isInCatch(cmpl *compiler) bool {
-	for _, item := range cmpl.owners {
+	for _, item := range jumpOwners(cmpl) {
 		if item.GetType() == core.CtStack {
 			parent := item.(*core.CmdBlock).Parent
 			if parent != nil && parent.ID == core.StackTry && len(parent.Children) == 2 &&
@@ -0,0 +1,238 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"github.com/gentee/gentee/core"
+)
+
+// tupleOf returns the tuple type with the specified types of items
+func tupleOf(cmpl *compiler, types []*core.TypeObject) *core.TypeObject {
+	if obj := cmpl.unit.FindType(core.TupleName(types)); obj != nil &&
+		obj.(*core.TypeObject).IsTuple() {
+		return obj.(*core.TypeObject)
+	}
+	return cmpl.unit.NewTupleType(types).(*core.TypeObject)
+}
+
+// tupleType reads the result types (type1, type2, ...) and returns the tuple type.
+// It returns false if the parenthesis don't follow the parameters of the function
+func tupleType(cmpl *compiler) (*core.TypeObject, bool, error) {
+	var types []*core.TypeObject
+	lp := cmpl.unit.Lexeme
+	start := cmpl.pos
+	if lp.Tokens[start-1].Type != tkRPar {
+		return nil, false, nil
+	}
+	i := start + 1
+	for {
+		if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkIdent {
+			return nil, true, cmpl.ErrorPos(i, ErrType)
+		}
+		cmpl.pos = i
+		obj, err := getType(cmpl)
+		if err != nil {
+			return nil, true, err
+		}
+		types = append(types, obj.(*core.TypeObject))
+		if i++; i < len(lp.Tokens) && lp.Tokens[i].Type == tkComma {
+			i++
+			continue
+		}
+		if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkRPar {
+			return nil, true, cmpl.ErrorPos(i, ErrNotRPar)
+		}
+		break
+	}
+	cmpl.pos = start
+	cmpl.newPos = i
+	if len(types) == 1 {
+		return types[0], true, nil
+	}
+	return tupleOf(cmpl, types), true, nil
+}
+
+func coFuncTuple(cmpl *compiler) error {
+	result, ok, err := tupleType(cmpl)
+	if !ok || err != nil {
+		return err
+	}
+	cmpl.latestFunc().Block.Result = result
+	cmpl.dynamic = &cmState{tkLPar, cmLCurly, nil, nil, 0}
+	return coFuncStart(cmpl)
+}
+
+func coClosureTuple(cmpl *compiler) error {
+	result, ok, err := tupleType(cmpl)
+	if !ok || err != nil {
+		return err
+	}
+	cmpl.latestFunc().Block.Result = result
+	cmpl.dynamic = &cmState{tkLPar, cmLCurly, nil, nil, 0}
+	return coClosureStart(cmpl)
+}
+
+func coLocalTuple(cmpl *compiler) error {
+	result, ok, err := tupleType(cmpl)
+	if !ok || err != nil {
+		return err
+	}
+	cmpl.curOwner().Result = result
+	cmpl.dynamic = &cmState{tkLPar, cmLCurly, nil, nil, 0}
+	return coLocalStart(cmpl)
+}
+
+func coFnTuple(cmpl *compiler) error {
+	result, ok, err := tupleType(cmpl)
+	if !ok || err != nil {
+		return err
+	}
+	cmpl.curType.Func.Result = result
+	cmpl.dynamic = &cmState{tkLPar, cmFnParams, nil, nil, 0}
+	return nil
+}
+
+// newTuple returns the command which creates the tuple from the values
+func newTuple(cmpl *compiler, values []core.ICmd) *core.CmdBlock {
+	types := make([]*core.TypeObject, len(values))
+	for i, item := range values {
+		types[i] = item.GetResult()
+	}
+	obj := cmpl.unit.FindObj(core.DefNewKeyValue)
+	tuple := &core.CmdBlock{ID: core.StackNew, Result: tupleOf(cmpl, types),
+		CmdCommon: core.CmdCommon{TokenID: uint32(values[0].GetToken())}}
+	for i, item := range values {
+		tuple.Children = append(tuple.Children, &core.CmdBinary{
+			CmdCommon: core.CmdCommon{TokenID: uint32(item.GetToken())},
+			Object:    obj, Result: obj.Result(), Right: item,
+			Left: &core.CmdValue{Value: int64(i), Result: cmpl.getIntType(),
+				CmdCommon: core.CmdCommon{TokenID: uint32(item.GetToken())}}})
+	}
+	return tuple
+}
+
+// destruct contains the variables which get the items of the tuple
+type destruct struct {
+	Tuple *core.CmdVar
+	Vars  []*core.CmdVar
+}
+
+// coDestruct defines the variables which get the items of the tuple in
+// type1 a, [type2] b, ... = exp
+func coDestruct(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if cmpl.curOptional {
+		return cmpl.Error(ErrOptional)
+	}
+	block := cmpl.curOwner()
+	vars := []*core.CmdVar{{Block: block, Index: len(block.Vars) - 1,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}}
+	i := cmpl.pos + 1
+	for i < len(lp.Tokens) && lp.Tokens[i].Type == tkComma {
+		i++
+		if i+1 < len(lp.Tokens) && lp.Tokens[i].Type == tkIdent &&
+			lp.Tokens[i+1].Type == tkIdent {
+			cmpl.pos = i
+			if err := coVarType(cmpl); err != nil {
+				return err
+			}
+			i++
+		}
+		if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkIdent {
+			return cmpl.ErrorPos(i, ErrName)
+		}
+		cmpl.pos = i
+		if err := coVar(cmpl); err != nil {
+			return err
+		}
+		vars = append(vars, &core.CmdVar{Block: block, Index: len(block.Vars) - 1,
+			CmdCommon: core.CmdCommon{TokenID: uint32(i)}})
+		i++
+	}
+	if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkAssign {
+		return cmpl.ErrorPos(i, ErrMustAssign)
+	}
+	cmpl.dynamic = &cmState{tkAssign, cmExp, nil, nil, 0}
+	return destructStart(cmpl, vars, i)
+}
+
+// coDestructAssign assigns the items of the tuple to the existing variables in
+// a, b, ... = exp
+func coDestructAssign(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	vars := []*core.CmdVar{cmpl.exp[0].(*core.CmdVar)}
+	i := cmpl.pos
+	for i < len(lp.Tokens) && lp.Tokens[i].Type == tkComma {
+		if i++; i >= len(lp.Tokens) || lp.Tokens[i].Type != tkIdent {
+			return cmpl.ErrorPos(i, ErrName)
+		}
+		token := getToken(lp, i)
+		cmdVar := findVar(cmpl, token)
+		if cmdVar == nil {
+			return cmpl.ErrorPos(i, ErrUnknownIdent, token)
+		}
+		cmdVar.TokenID = uint32(i)
+		vars = append(vars, cmdVar)
+		i++
+	}
+	if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkAssign {
+		return cmpl.ErrorPos(i, ErrMustAssign)
+	}
+	return destructStart(cmpl, vars, i)
+}
+
+// destructStart starts the expression which assigns the tuple to the hidden variable.
+// The items of the tuple are assigned to the variables by destructEnd
+func destructStart(cmpl *compiler, vars []*core.CmdVar, pos int) error {
+	types := make([]*core.TypeObject, len(vars))
+	for i, item := range vars {
+		types[i] = item.GetResult()
+	}
+	block := cmpl.curOwner()
+	tuple := &core.CmdVar{Block: block, Index: len(block.Vars),
+		CmdCommon: core.CmdCommon{TokenID: uint32(pos)}}
+	block.Vars = append(block.Vars, tupleOf(cmpl, types))
+	coExpStart(cmpl)
+	appendExp(cmpl, tuple)
+	cmpl.pos = pos
+	if err := appendExpBuf(cmpl, tkAssign); err != nil {
+		return err
+	}
+	cmpl.newPos = pos
+	cmpl.destructs = append(cmpl.destructs, destruct{Tuple: tuple, Vars: vars})
+	return nil
+}
+
+// destructEnd assigns the items of the tuple to the variables if the command assigns
+// the tuple to the hidden variable
+func destructEnd(cmpl *compiler, icmd core.ICmd) error {
+	if len(cmpl.destructs) == 0 || icmd.GetType() != core.CtStack ||
+		icmd.(*core.CmdBlock).ID != core.StackAssign ||
+		icmd.(*core.CmdBlock).Children[0] != cmpl.destructs[len(cmpl.destructs)-1].Tuple {
+		return nil
+	}
+	item := cmpl.destructs[len(cmpl.destructs)-1]
+	cmpl.destructs = cmpl.destructs[:len(cmpl.destructs)-1]
+	// the hidden variable is only read by the assignments of the items so the tuple
+	// is assigned by the pointer without copying
+	if assign := icmd.(*core.CmdBlock); assign.Object ==
+		cmpl.ws.StdLib().FindObj(core.DefAssignStructStruct) {
+		assign.Object = cmpl.ws.StdLib().FindObj(core.DefAssignBitAndStructStruct)
+	}
+	types := item.Tuple.GetResult().Custom.Types
+	for i, cmdVar := range item.Vars {
+		coExpStart(cmpl)
+		appendExp(cmpl, cmdVar)
+		appendExp(cmpl, &core.CmdVar{Block: item.Tuple.Block, Index: item.Tuple.Index,
+			CmdCommon: item.Tuple.CmdCommon, Indexes: []core.CmdRet{{Cmd: &core.CmdValue{
+				Value: int64(i), Result: cmpl.getIntType(), CmdCommon: item.Tuple.CmdCommon},
+				Type: types[i]}}})
+		cmpl.expbuf = append(cmpl.expbuf, ExpBuf{Oper: tkAssign, Pos: int(cmdVar.TokenID)})
+		if err := coExpEnd(cmpl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
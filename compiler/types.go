@@ -34,6 +34,9 @@ func InitTypes(ws *core.Workspace) {
 		{`keyval`, reflect.TypeOf(core.KeyValue{}), ``},
 		{`struct`, typeStruct, ``},
 		{`fn`, reflect.TypeOf(core.Fn{}), ``},
+		{`iface`, reflect.TypeOf(core.Iface{}), ``},
+		// enum is for embedded enum funcs. It means any enum type
+		{`enum`, reflect.TypeOf(int64(0)), ``},
 		{`thread`, reflect.TypeOf(int64(0)), ``},
 		{`error`, reflect.TypeOf(core.RuntimeError{}), ``},
 		{`obj`, reflect.TypeOf(core.Obj{}), ``},
@@ -56,6 +59,10 @@ func InitTypes(ws *core.Workspace) {
 		if item.name == `obj` {
 			tobj.(*core.TypeObject).IndexOf = tobj.(*core.TypeObject)
 		}
+		if item.name == `iface` {
+			// iface is the interface without methods which accepts any struct
+			tobj.(*core.TypeObject).Iface = &core.IfaceType{}
+		}
 	}
 	// Define aliases
 	ws.StdLib().NameSpace[`@arr`] = ws.StdLib().NameSpace[`@arr.str`]
@@ -35,9 +35,10 @@ type CodePos struct {
 }
 
 type StructInfo struct {
-	Name   string
-	Fields []uint16 // types
-	Keys   []string
+	Name    string
+	Fields  []uint16 // types
+	Keys    []string
+	Methods map[string]int32 // ids of methods which are called by interfaces
 }
 
 type Exec struct {
@@ -65,6 +66,7 @@ type Embed struct {
 	Variadic bool        // variadic function
 	Runtime  bool        // the first parameter is rt
 	CanError bool        // can generate error
+	Tuple    bool        // returns several values as a tuple
 }
 
 type AssignIntFunc func(*int64, int64) (int64, error)
@@ -76,6 +78,7 @@ type AssignAnyFunc func(interface{}, interface{}) (interface{}, error)
 
 const (
 	TYPENONE   = 0
+	TYPEPARAM  = 0x005 // the type parameter of generic embedded function
 	TYPEINT    = 0x011
 	TYPEBOOL   = 0x021
 	TYPECHAR   = 0x031
@@ -89,6 +92,7 @@ const (
 	TYPEERROR  = 0x064
 	TYPESET    = 0x074
 	TYPEOBJ    = 0x084
+	TYPEIFACE  = 0x094
 	TYPESTRUCT = 0x104
 
 	BlBreak    = 0x0001
@@ -98,6 +102,12 @@ const (
 	BlTry      = 0x0010
 	BlRecover  = 0x0020
 	BlRetry    = 0x0040
+
+	// DeferFinally is a flag of DEFER. It means that the deferred block is finally of try
+	DeferFinally = 0x0001
+
+	// GoKept is a flag of GOBYID. It means that the error of the thread is kept for ErrOf
+	GoKept = 0x8000
 )
 
 const (
@@ -170,11 +180,15 @@ const (
 	END       // end of the function
 	CONSTBYID // + int32 id of the object
 	CALLBYID  // & (par count<<16) + int32 id of the object
-	GOBYID    // & (par count<<16) + int32 id of the object new thread + int32 type of pars   60
+	GOBYID    // & (par count<<16) [| GoKept] + int32 id of the object new thread + int32 type of pars   60
 	EMBED     // & (embed id << 16) calls embedded func + int32 count for variadic funcs
 	// + [variadic types]
-	LOCAL // & (par count << 16)+ int32 offset
-	IOTA  // & (iota<<16)
+	LOCAL      // & (par count << 16)+ int32 offset
+	IOTA       // & (iota<<16)
+	TIMEOUT    // sets the deadline for the next block
+	TIMEOUTEND // removes the deadline of the block
+	DEFER      // & (flags<<16) + int32 offset registers the following deferred block
+	CALLIFACE  // + int32 count of any parameters pushes the method of the interface
 
 	INDEX        // & (int32 count) + {(type input<<16) + result type}
 	ASSIGNPTR    // & (int16 type << 16)
@@ -29,6 +29,14 @@ const (
 	ConstRecursive = `RECURSIVE`
 	ConstOnlyFiles = `ONLYFILES`
 	ConstRegExp    = `REGEXP`
+	// The statuses of the thread for Status(thread)
+	ConstThQueue    = `THQUEUE`
+	ConstThWork     = `THWORK`
+	ConstThPaused   = `THPAUSED`
+	ConstThWait     = `THWAIT`
+	ConstThFinished = `THFINISHED`
+	ConstThError    = `THERROR`
+	ConstThClosed   = `THCLOSED`
 
 	// NotIota means that constant doesn't use IOTA
 	NotIota = -1
@@ -86,6 +86,16 @@ const (
 	StackLocret
 	// StackTry is the try statement
 	StackTry
+	// StackTimeout is the timeout statement
+	StackTimeout
+	// StackSafe is the chain of ?. operators. It is compiled like ?(condition, exp1, exp2)
+	StackSafe
+	// StackDefer is the defer statement
+	StackDefer
+	// StackIface converts the struct value to the interface
+	StackIface
+	// StackMethod gets the method of the interface value
+	StackMethod
 )
 
 // Token is a lexical token.
@@ -167,6 +177,7 @@ type CmdBlock struct {
 	Locals     []ICmd
 	LocalNames map[string]int
 	Children   []ICmd
+	Captured   map[int]*CmdVar // the boxes of the variables captured by closures
 }
 
 // CmdUnary calls an unary function
@@ -195,6 +206,7 @@ type CmdAnyFunc struct {
 	FnVar    ICmd
 	Optional []int // indexes of optional variables
 	IsThread bool
+	IsKept   bool // the thread is assigned to a variable and keeps its error
 }
 
 // GetType returns CtValue
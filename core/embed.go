@@ -16,6 +16,8 @@ const (
 	DefAssignAddArrArr = `AssignAddºArrArr`
 	// DefAssignAddMap appends the map to array
 	DefAssignAddMap = `AssignAddºArrMap`
+	// DefAssignAddFn appends the fn value to array
+	DefAssignAddFn = `AssignAddºArrFn`
 	// DefAssignArr assigns one array to another
 	DefAssignArr = `AssignºArrArr`
 	// DefAssignMap assigns one map to another
@@ -32,6 +34,8 @@ const (
 	DefAssignBitAndStructStruct = `AssignBitAndºStructStruct`
 	// DefAssignFnFn equals fn = fn
 	DefAssignFnFn = `AssignºFnFn`
+	// DefAssignIfaceIface equals iface = iface
+	DefAssignIfaceIface = `AssignºIfaceIface`
 	// DefAssignBitAndArrArr equals arr &= arr
 	DefAssignBitAndArrArr = `AssignBitAndºArrArr`
 	// DefAssignBitAndMapMap equals map &= map
@@ -47,6 +51,7 @@ var (
 		DefAssignAddArr:             true,
 		DefAssignAddArrArr:          true,
 		DefAssignAddMap:             true,
+		DefAssignAddFn:              true,
 		DefAssignArr:                true,
 		DefAssignMap:                true,
 		DefLenArr:                   true,
@@ -54,6 +59,7 @@ var (
 		DefAssignIntInt:             true,
 		DefAssignStructStruct:       true,
 		DefAssignFnFn:               true,
+		DefAssignIfaceIface:         true,
 		DefAssignBitAndStructStruct: true,
 		DefAssignBitAndArrArr:       true,
 		DefAssignBitAndMapMap:       true,
@@ -66,6 +72,28 @@ var (
 // It creates a new type if it absents.
 func (unit *Unit) NameToType(name string) IObject {
 	obj := unit.FindType(name)
+	if items, ok := SplitTuple(name); ok && obj == nil {
+		types := make([]*TypeObject, len(items))
+		for i, item := range items {
+			itemType := unit.NameToType(item)
+			if itemType == nil {
+				return nil
+			}
+			types[i] = itemType.(*TypeObject)
+		}
+		return unit.NewTupleType(types)
+	}
+	if key, item, ok := SplitMapKey(name); ok && obj == nil {
+		switch key {
+		case `str`:
+			obj = unit.NameToType(`map.` + item)
+		case `int`:
+			if indexOf := unit.NameToType(item); indexOf != nil {
+				obj = unit.NewMapType(name, indexOf, unit.NameToType(key))
+			}
+		}
+		return obj
+	}
 	if obj == nil {
 		ins := strings.SplitN(name, `.`, 2)
 		if len(ins) == 2 {
@@ -92,25 +120,38 @@ func (unit *Unit) ImportEmbed(embed Embed) {
 		retType  *TypeObject
 		parTypes []*TypeObject
 		fnc      interface{}
+		generic  *Generic
 	)
+	name := embed.Name
+	if lt := strings.IndexRune(name, '<'); lt > 0 && strings.HasSuffix(name, `>`) {
+		generic = &Generic{
+			Types:  strings.Split(name[lt+1:len(name)-1], `,`),
+			Result: embed.Ret,
+		}
+		name = name[:lt]
+	}
 	if embed.Func == nil {
 		code = []Bcode{Bcode(embed.Code)}
 	} else {
 		fnc = int32(embed.Code)
 	}
 	if len(embed.Ret) > 0 {
-		retType = unit.NameToType(embed.Ret).(*TypeObject)
+		retType = unit.NameToType(generic.anyType(embed.Ret)).(*TypeObject)
 	}
 	if len(embed.Pars) > 0 {
 		pars := strings.Split(embed.Pars, `,`)
 		parTypes = make([]*TypeObject, len(pars))
 		for i, item := range pars {
-			parTypes[i] = unit.NameToType(strings.TrimSpace(item)).(*TypeObject)
+			item = strings.TrimSpace(item)
+			if generic != nil {
+				generic.Params = append(generic.Params, item)
+			}
+			parTypes[i] = unit.NameToType(generic.anyType(item)).(*TypeObject)
 		}
 	}
 	obj := unit.NewObject(&EmbedObject{
 		Object: Object{
-			Name: embed.Name,
+			Name: name,
 			Unit: unit,
 			BCode: Bytecode{
 				Code: code,
@@ -122,15 +163,43 @@ func (unit *Unit) ImportEmbed(embed Embed) {
 		Variadic: embed.Variadic,
 		Runtime:  embed.Runtime,
 		CanError: embed.CanError,
+		Generic:  generic,
 	})
 	ind := len(unit.VM.Objects) - 1
 	if defFuncs[embed.Name] {
 		unit.NameSpace[embed.Name] = uint32(ind) | NSPub
 		return
 	}
-	if strings.HasSuffix(embed.Name, `Auto`) {
-		unit.NameSpace[`?`+embed.Name[:len(embed.Name)-4]] = uint32(ind) | NSPub
+	if generic != nil {
+		unit.AddGeneric(ind, obj, true)
 		return
 	}
 	unit.AddFunc(ind, obj, true)
 }
+
+// anyType replaces the type parameter of arr.T, map.T or map[int].T with arr* or map*.
+// The type parameter T is replaced with obj, fn.T.R is replaced with fn and thread.T
+// is replaced with thread
+func (generic *Generic) anyType(name string) string {
+	if generic == nil {
+		return name
+	}
+	if strings.HasPrefix(name, `fn.`) {
+		// fn.T.bool is fn with the parameter T and bool result
+		return `fn`
+	}
+	if strings.HasPrefix(name, `thread.`) {
+		return `thread`
+	}
+	for _, item := range generic.Types {
+		if name == item {
+			// the actual type of the value is defined in the bytecode of the call
+			return `obj`
+		}
+		if strings.HasSuffix(name, `.`+item) {
+			// the type of keys doesn't matter for embedded functions
+			return strings.TrimSuffix(name[:len(name)-len(item)-1], `[int]`) + `*`
+		}
+	}
+	return name
+}
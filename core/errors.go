@@ -6,6 +6,7 @@ package core
 
 import (
 	"fmt"
+	"hash/crc32"
 	"path/filepath"
 	"strings"
 )
@@ -95,3 +96,8 @@ func ErrFormat(path string, line, pos int, message string) string {
 	return strings.TrimSpace(fmt.Sprintf(`%s [%d:%d] %s`, path, line, pos, message))
 
 }
+
+// ErrKindID returns the identifier of the error kind which is declared as error Name
+func ErrKindID(name string) int {
+	return int(crc32.ChecksumIEEE([]byte(name))&0x3fffffff | 0x40000000)
+}
@@ -5,6 +5,7 @@
 package core
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -14,6 +15,7 @@ const (
 	npConst    = `$`
 	npVariadic = `?`
 	npFunc     = `#`
+	npGeneric  = `<`
 
 	// NSImported means imported object in NameSpace
 	NSImported = 0x10000000
@@ -48,6 +50,7 @@ func (unit *Unit) FindConst(name string) IObject {
 
 // FindFunc returns the function with the specified name and parameters
 func (unit *Unit) FindFunc(name string, params []*TypeObject) (IObject, bool) {
+	var enum *TypeObject
 	key := npFunc + name
 	keyAny := key
 	for _, v := range params {
@@ -58,8 +61,14 @@ func (unit *Unit) FindFunc(name string, params []*TypeObject) (IObject, bool) {
 		key += npFunc + parName
 		if strings.HasPrefix(parName, `arr.`) {
 			keyAny += npFunc + `arr*`
-		} else if strings.HasPrefix(parName, `map.`) {
+		} else if strings.HasPrefix(parName, `map.`) || strings.HasPrefix(parName, `map[`) {
 			keyAny += npFunc + `map*`
+		} else if v.Iface != nil {
+			keyAny += npFunc + `iface`
+		} else if v.Enum != nil && (enum == nil || enum == v) {
+			// the parameters of embedded functions must have the same enum type
+			enum = v
+			keyAny += npFunc + `enum`
 		} else {
 			keyAny += npFunc + parName
 		}
@@ -75,6 +84,29 @@ func (unit *Unit) FindFunc(name string, params []*TypeObject) (IObject, bool) {
 	return unit.FindObj(npVariadic + name), true
 }
 
+// Ifaces returns the interface types which are available in the unit sorted by name
+func (unit *Unit) Ifaces() []*TypeObject {
+	var names []string
+	for key := range unit.NameSpace {
+		if strings.HasPrefix(key, npType) {
+			if obj, ok := unit.FindObj(key).(*TypeObject); ok && obj.Iface != nil {
+				names = append(names, key)
+			}
+		}
+	}
+	sort.Strings(names)
+	ret := make([]*TypeObject, len(names))
+	for i, name := range names {
+		ret[i] = unit.FindObj(name).(*TypeObject)
+	}
+	return ret
+}
+
+// FindGeneric returns the generic function with the specified name
+func (unit *Unit) FindGeneric(name string) IObject {
+	return unit.FindObj(npGeneric + name)
+}
+
 // AddConst appends a constant to NameSpace
 func (unit *Unit) AddConst(name string) {
 	ind := uint32(len(unit.VM.Objects) - 1)
@@ -102,3 +134,19 @@ func (unit *Unit) AddFunc(ind int, obj IObject, pub bool) {
 	}
 	unit.NameSpace[key] = uint32(ind)
 }
+
+// AddGeneric appends the generic func to NameSpace
+func (unit *Unit) AddGeneric(ind int, obj IObject, pub bool) {
+	if pub {
+		ind |= NSPub
+	}
+	key := npGeneric + obj.GetName()
+	// generic functions can be overloaded by the types of parameters
+	switch v := obj.(type) {
+	case *EmbedObject:
+		v.Generic.Next = unit.FindObj(key)
+	case *FuncObject:
+		v.Generic.Next = unit.FindObj(key)
+	}
+	unit.NameSpace[key] = uint32(ind)
+}
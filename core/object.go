@@ -6,6 +6,8 @@ package core
 
 import (
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // ObjectType is used for types of objects
@@ -48,8 +50,11 @@ type TypeObject struct {
 	Object
 	Original reflect.Type // Original golang type
 	IndexOf  *TypeObject  // consists of elements
+	KeyOf    *TypeObject  // the type of keys for maps with int keys
 	Custom   *StructType  // for custom struct type
 	Func     *FnType      // for func type
+	Iface    *IfaceType   // for interface type
+	Enum     []string     // the names of items for enum type
 }
 
 // EmbedObject contains information about the golang function
@@ -61,12 +66,24 @@ type EmbedObject struct {
 	Variadic bool          // variadic function
 	Runtime  bool          // the first parameter is rt
 	CanError bool          // can generate error
+	Generic  *Generic      // for generic functions
 }
 
 // FuncObject contains information about the function
 type FuncObject struct {
 	Object
-	Block CmdBlock
+	Block   CmdBlock
+	Generic *Generic // for generic functions
+	Lex     *Lex     // the source of the instance of the generic function
+}
+
+// Generic contains information about the generic function
+type Generic struct {
+	Types  []string // the names of type parameters
+	Params []string // the types of parameters
+	Result string   // the type of the result
+	Lex    *Lex     // the source of the generic function
+	Next   IObject  // the next generic function with the same name
 }
 
 // ConstObject contains information about the constant
@@ -129,6 +146,9 @@ func (funcObj *FuncObject) GetName() string {
 
 // GetLex returns the lex structure of the object
 func (funcObj *FuncObject) GetLex() *Lex {
+	if funcObj.Lex != nil {
+		return funcObj.Lex
+	}
 	return funcObj.Object.Unit.Lexeme
 }
 
@@ -275,6 +295,79 @@ func (unit *Unit) NewType(name string, original reflect.Type, indexOf IObject) I
 	return &typeObject
 }
 
+// IntKeyMap is the prefix of the names of maps with int keys
+const IntKeyMap = `map[int].`
+
+// NewMapType adds a new map type with int keys to Unit
+func (unit *Unit) NewMapType(name string, indexOf, keyOf IObject) IObject {
+	obj := unit.NewType(name, reflect.TypeOf(Map{}), indexOf)
+	obj.(*TypeObject).KeyOf = keyOf.(*TypeObject)
+	return obj
+}
+
+// SplitMapKey splits the name like map[key].type into the key and the type of items
+func SplitMapKey(name string) (key, item string, ok bool) {
+	if !strings.HasPrefix(name, `map[`) {
+		return
+	}
+	end := strings.Index(name, `].`)
+	if end < 0 {
+		return
+	}
+	return name[4:end], name[end+2:], true
+}
+
+// TupleName returns the name of the tuple type like (type1,type2)
+func TupleName(types []*TypeObject) string {
+	names := make([]string, len(types))
+	for i, item := range types {
+		names[i] = item.GetName()
+	}
+	return `(` + strings.Join(names, `,`) + `)`
+}
+
+// NewTupleType adds a new tuple type to Unit. The tuple is a struct with the fields 0, 1, ...
+func (unit *Unit) NewTupleType(types []*TypeObject) IObject {
+	fields := make(map[string]int64)
+	for i := range types {
+		fields[strconv.Itoa(i)] = int64(i)
+	}
+	obj := unit.NewType(TupleName(types), reflect.TypeOf(Struct{}), nil)
+	obj.(*TypeObject).Custom = &StructType{
+		Fields: fields,
+		Types:  append([]*TypeObject{}, types...),
+	}
+	return obj
+}
+
+// IsTuple returns true if the type is a tuple of several values
+func (typeObj *TypeObject) IsTuple() bool {
+	return typeObj.Custom != nil && strings.HasPrefix(typeObj.Name, `(`)
+}
+
+// SplitTuple splits the name like (type1,type2) into the names of the types
+func SplitTuple(name string) (items []string, ok bool) {
+	if !strings.HasPrefix(name, `(`) || !strings.HasSuffix(name, `)`) {
+		return
+	}
+	var depth, start int
+	name = name[1 : len(name)-1]
+	for i, ch := range name {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, name[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(items, name[start:]), true
+}
+
 // IsVariadic returns true if th efunction is variadic
 func IsVariadic(obj IObject) bool {
 	return (obj.GetType() == ObjFunc && obj.(*FuncObject).Block.Variadic) ||
@@ -62,6 +62,24 @@ type FnType struct {
 // Fn is used for custom func types
 type Fn struct {
 	Func IObject
+	Env  []Capture // the captured variables of the closure
+}
+
+// Capture is the variable which is captured by the closure
+type Capture struct {
+	Var int     // the index of the variable in the closure
+	Box *CmdVar // the box of the variable in the enclosing function
+}
+
+// IfaceType is used for interface types
+type IfaceType struct {
+	Methods []string                  // Names of methods
+	Funcs   []*FnType                 // Types of methods without the receiver
+	Impls   map[*TypeObject][]IObject // Methods of struct types converted to the interface
+}
+
+// Iface is used for interface types
+type Iface struct {
 }
 
 // StructType is used for custom struct types
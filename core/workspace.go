@@ -20,6 +20,7 @@ type Workspace struct {
 	Linked    map[string]int // compiled files
 	IotaID    int32
 	Embedded  []Embed
+	ErrKinds  map[int]*ConstObject // error kinds by their identifiers
 }
 
 const (
@@ -67,6 +68,7 @@ func NewVM(Embedded []Embed) *Workspace {
 		Objects:   make([]IObject, 0, 500),
 		Linked:    make(map[string]int),
 		Embedded:  Embedded,
+		ErrKinds:  make(map[int]*ConstObject),
 	}
 	return &ws
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -20,6 +21,10 @@ const (
 	SysSuspend   = vm.SysSuspend
 	SysResume    = vm.SysResume
 	SysTerminate = vm.SysTerminate
+
+	EvStart  = vm.EvStart
+	EvFinish = vm.EvFinish
+	EvError  = vm.EvError
 )
 
 // Exec is a structure with a bytecode that is ready to run
@@ -37,6 +42,11 @@ type Settings struct {
 	vm.Settings
 }
 
+// Handle is a structure for the management of the script started by RunAsync
+type Handle struct {
+	*vm.Handle
+}
+
 // Gentee is a common structure for compiling and executing Gentee source code
 type Gentee struct {
 	*core.Workspace
@@ -102,7 +112,7 @@ func str2pars(in string) (types []uint16) {
 }
 
 func Customize(custom *Custom) error {
-	re, err := regexp.Compile(`^([\wº]+)\(([\w ,\.\*]*)\)\s*([\w\.\*]*)?`)
+	re, err := regexp.Compile(`^([\wº]+)\(([\w ,\.\*\[\]]*)\)\s*([\w\.\*\[\]\(\),]*)?`)
 	if err != nil {
 		return err
 	}
@@ -128,6 +138,7 @@ func Customize(custom *Custom) error {
 			Variadic: t.IsVariadic(),
 			Runtime:  t.NumIn() > 0 && t.In(0) == reflect.TypeOf(&vm.Runtime{}),
 			CanError: t.NumOut() >= 1 && t.Out(t.NumOut()-1).String() == `error`,
+			Tuple:    strings.HasPrefix(vals[3], `(`),
 		}
 		vm.EmbedFuncs = append(vm.EmbedFuncs, embed)
 	}
@@ -184,6 +195,16 @@ func (exec *Exec) Run(settings Settings) (interface{}, error) {
 	return vm.Run(exec.Exec, settings.Settings)
 }
 
+// RunAsync executes the bytecode in the background. It returns the handle for getting
+// information about threads and managing them.
+func (exec *Exec) RunAsync(settings Settings) (*Handle, error) {
+	handle, err := vm.RunAsync(exec.Exec, settings.Settings)
+	if err != nil {
+		return nil, err
+	}
+	return &Handle{Handle: handle}, nil
+}
+
 // Go2GenteeType converts go type to gentee type
 func Go2GenteeType(goval interface{}, gtype ...string) (interface{}, error) {
 	var (
@@ -248,12 +269,25 @@ func Go2GenteeType(goval interface{}, gtype ...string) (interface{}, error) {
 		case reflect.Map:
 			keys := rval.MapKeys()
 			gmap := core.NewMap()
+			intKeys := false
+			switch rval.Type().Key().Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				// int keys are stored as decimal strings in the numerical order
+				intKeys = true
+				sort.Slice(keys, func(i, j int) bool {
+					return keys[i].Int() < keys[j].Int()
+				})
+			}
 			for _, key := range keys {
 				tmp, err := Go2GenteeType(rval.MapIndex(key).Interface(), subtype)
 				if err != nil {
 					return nil, err
 				}
-				gmap.SetIndex(key.String(), tmp)
+				if intKeys {
+					gmap.SetIndex(strconv.FormatInt(key.Int(), 10), tmp)
+				} else {
+					gmap.SetIndex(key.String(), tmp)
+				}
 			}
 			val = gmap
 		}
@@ -304,6 +338,14 @@ func Gentee2GoType(gval interface{}, gtype ...string) interface{} {
 		}
 		return ret
 	case *core.Map:
+		if len(types) > 0 && types[0] == `map[int]` {
+			ret := make(map[int64]interface{})
+			for _, key := range v.Keys {
+				ikey, _ := strconv.ParseInt(key, 10, 64)
+				ret[ikey] = Gentee2GoType(v.Data[key], subtype)
+			}
+			return ret
+		}
 		ret := make(map[string]interface{})
 		for _, key := range v.Keys {
 			ret[key] = Gentee2GoType(v.Data[key], subtype)
@@ -337,6 +379,12 @@ func Gentee2GoType(gval interface{}, gtype ...string) interface{} {
 	return nil
 }
 
+// ErrorKind returns the error which matches the script errors of the kind declared
+// as error Name. It can be used with errors.Is.
+func ErrorKind(name string) error {
+	return &vm.RuntimeError{ID: core.ErrKindID(name), Message: name}
+}
+
 // Version returns the current version of the Gentee compiler.
 func Version() string {
 	return core.Version
@@ -2,4 +2,10 @@ module github.com/gentee/gentee
 
 go 1.14
 
-require github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510
+require (
+	github.com/andybalholm/brotli v1.1.0
+	github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510
+	github.com/klauspost/compress v1.12.3
+	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b
+	gopkg.in/yaml.v3 v3.0.1
+)
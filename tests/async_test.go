@@ -0,0 +1,85 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	gentee "github.com/gentee/gentee"
+	"github.com/gentee/gentee/vm"
+)
+
+func TestRunAsync(t *testing.T) {
+	workspace := gentee.New()
+	exec, _, err := workspace.Compile(`run int {
+  thread th = go {
+    for i in 1..100 : sleep(50)
+  }
+  thread terr = go {
+    sleep(100)
+    error(100, "thread error")
+  }
+  ErrOf(terr)
+  wait(th)
+  return 7
+}`, `async.g`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	handle, err := exec.RunAsync(gentee.Settings{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(300 * time.Millisecond)
+	threads := handle.Threads()
+	if len(threads) != 3 {
+		t.Errorf(`wrong count of threads %d`, len(threads))
+		return
+	}
+	if threads[1].Status != vm.ThWork || threads[1].Line != 3 ||
+		threads[1].Path != `async.g` || threads[1].Start.IsZero() {
+		t.Errorf(`wrong thread info %v`, threads[1])
+		return
+	}
+	if threads[2].Status != vm.ThError {
+		t.Errorf(`wrong status %d`, threads[2].Status)
+		return
+	}
+	if err = handle.Suspend(1); err != nil {
+		t.Error(err)
+		return
+	}
+	if status := handle.Threads()[1].Status; status != vm.ThPaused {
+		t.Errorf(`wrong status %d`, status)
+		return
+	}
+	if err = handle.Resume(1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = handle.Terminate(1); err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := handle.Wait()
+	if err != nil || result != int64(7) {
+		t.Errorf(`wrong result %v %v`, result, err)
+		return
+	}
+	var events []string
+	for event := range handle.Events {
+		events = append(events, fmt.Sprintf(`%d:%d`, event.ID, event.Type))
+	}
+	if fmt.Sprint(events) != `[0:0 1:0 2:0 2:2 1:1 0:1]` {
+		t.Errorf(`wrong events %v`, events)
+	}
+	if err = handle.Suspend(5); err == nil || err.Error() != `invalid thread` {
+		t.Errorf(`wrong error %v`, err)
+	}
+}
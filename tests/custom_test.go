@@ -198,7 +198,33 @@ func cnv6(in *core.Array) (*core.Map, error) {
 	return mymap, err
 }
 
+func cnv7(in *core.Map) (*core.Map, error) {
+	my := gentee.Gentee2GoType(in, `map[int].int`).(map[int64]interface{})
+	out := make(map[int64]int64)
+	for key, v := range my {
+		out[key] = v.(int64)
+		out[key*10] = v.(int64) + 1
+	}
+	ret, err := gentee.Go2GenteeType(out)
+	return ret.(*core.Map), err
+}
+
+func divMod(x, y int64) (int64, int64, error) {
+	if y == 0 {
+		return 0, 0, fmt.Errorf("division by zero")
+	}
+	return x / y, x % y, nil
+}
+
+func splitKey(in string) (string, string) {
+	list := strings.SplitN(in, `=`, 2)
+	return list[0], list[1]
+}
+
 var customLib = []gentee.EmbedItem{
+	{Prototype: `divMod(int, int) (int, int)`, Object: divMod},
+	{Prototype: `splitKey(str) (str, str)`, Object: splitKey},
+	{Prototype: `cnv7(map[int].int) map[int].int`, Object: cnv7},
 	{Prototype: `cnv6(arr*) map`, Object: cnv6},
 	{Prototype: `cnv5(set) set`, Object: cnv5},
 	{Prototype: `cnv4(obj) str`, Object: cnv4},
@@ -270,3 +296,45 @@ func TestCustom(t *testing.T) {
 		return
 	}
 }
+
+// BenchmarkEmbedTuple compares the embedded function returning the tuple with two calls of
+// the embedded function returning one value
+func BenchmarkEmbedTuple(b *testing.B) {
+	var registered bool
+	for _, embed := range vm.EmbedFuncs {
+		registered = registered || embed.Name == `divMod`
+	}
+	if !registered {
+		if err := gentee.Customize(&gentee.Custom{Embedded: customLib}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	workspace := gentee.New()
+	for _, item := range []struct {
+		name string
+		body string
+	}{
+		{`tuple`, `int d, m = divMod(i, 7)`},
+		{`single`, `int d = Sum(i, 7)
+		int m = Sum(7, i)`},
+	} {
+		exec, _, err := workspace.Compile(`run int {
+			int sum
+			for i in 1..1000 {
+				`+item.body+`
+				sum += d + m
+			}
+			return sum
+		}`, ``)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(item.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := exec.Run(gentee.Settings{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
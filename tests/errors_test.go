@@ -0,0 +1,48 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"errors"
+	"testing"
+
+	gentee "github.com/gentee/gentee"
+	"github.com/gentee/gentee/vm"
+)
+
+func TestErrorKind(t *testing.T) {
+	workspace := gentee.New()
+	exec, _, err := workspace.Compile(`error NotFound, Denied
+func load(str name) {
+  error(NotFound, "file %s not found", name)
+}
+run {
+  try {
+    load("app.conf")
+  } catch e {
+    error(Wrap(e, "loading config"))
+  }
+}`, `errkind.g`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = exec.Run(gentee.Settings{})
+	if err == nil {
+		t.Error(`error expected`)
+		return
+	}
+	if !errors.Is(err, gentee.ErrorKind(`NotFound`)) || errors.Is(err, gentee.ErrorKind(`Denied`)) {
+		t.Errorf(`wrong kind of error %v`, err)
+	}
+	var rterr *vm.RuntimeError
+	if !errors.As(err, &rterr) || rterr.Text() != `loading config: file app.conf not found` {
+		t.Errorf(`wrong error %v`, err)
+	}
+	if cause := errors.Unwrap(err); cause == nil ||
+		cause.(*vm.RuntimeError).Message != `file app.conf not found` {
+		t.Errorf(`wrong cause %v`, cause)
+	}
+}
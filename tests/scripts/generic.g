@@ -0,0 +1,3 @@
+pub func Second<T>(arr.T a) T : return a[1]
+
+func Third<T>(arr.T a) T : return a[2]
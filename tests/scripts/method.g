@@ -0,0 +1,12 @@
+pub struct mOrder {
+    str name
+    arr.int prices
+}
+
+pub func mOrder.Total() int {
+    int sum
+    for p in this.prices : sum += p
+    return sum
+}
+
+func mOrder.Count() int : return *this.prices
@@ -0,0 +1,550 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// archiveFile is a file or a directory which is added to the archive
+type archiveFile struct {
+	Path string // the path of the file
+	Name string // the name in the archive
+	Info os.FileInfo
+}
+
+// archiveOptions contains the filter and the progress callback of archive functions
+type archiveOptions struct {
+	Flags   int64
+	Pattern string
+	Worker  *Runtime
+	Fn      *Fn
+}
+
+// newArchiveOptions returns the options. If fn is not nil then the worker thread is created
+// and closeWorker must be called
+func newArchiveOptions(rt *Runtime, flags int64, pattern string, fn *Fn) (*archiveOptions,
+	error) {
+	ret := &archiveOptions{Flags: flags, Pattern: pattern, Fn: fn}
+	if fn != nil {
+		worker, err := arrWorker(rt, fn)
+		if err != nil {
+			return nil, err
+		}
+		ret.Worker = worker
+	}
+	return ret, nil
+}
+
+func (opts *archiveOptions) close() {
+	if opts.Worker != nil {
+		opts.Worker.closeWorker()
+	}
+}
+
+// match checks the name of the file like ReadDir does
+func (opts *archiveOptions) match(name string) (bool, error) {
+	var (
+		ok  int64
+		err error
+	)
+	if len(opts.Pattern) == 0 {
+		return true, nil
+	}
+	if opts.Flags&RegExp != 0 {
+		ok, err = MatchºStrStr(name, opts.Pattern)
+	} else {
+		ok, err = MatchPath(opts.Pattern, name)
+	}
+	return ok != 0, err
+}
+
+// progress calls the callback fn(str name, int size) for the processed file
+func (opts *archiveOptions) progress(name string, size int64) error {
+	if opts.Worker == nil {
+		return nil
+	}
+	_, err := opts.Worker.callFn(opts.Fn, name, size)
+	return err
+}
+
+// appendArchiveDir appends the files of the directory
+func appendArchiveDir(files []archiveFile, root, dir string, opts *archiveOptions) (
+	[]archiveFile, error) {
+	list, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range list {
+		path := filepath.Join(dir, info.Name())
+		if info.IsDir() {
+			if opts.Flags&Recursive == 0 {
+				continue
+			}
+			files = append(files, archiveFile{Path: path, Name: archiveName(root, path),
+				Info: info})
+			if files, err = appendArchiveDir(files, root, path, opts); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		ok, err := opts.match(info.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			files = append(files, archiveFile{Path: path, Name: archiveName(root, path),
+				Info: info})
+		}
+	}
+	return files, nil
+}
+
+func archiveName(root, path string) string {
+	name, _ := filepath.Rel(root, path)
+	return filepath.ToSlash(name)
+}
+
+// archiveFiles returns the list of files for adding to the archive. The directories are
+// added with their contents
+func archiveFiles(rt *Runtime, list *core.Array, opts *archiveOptions) ([]archiveFile, error) {
+	var files []archiveFile
+	for _, item := range list.Data {
+		path, err := filepath.Abs(item.(string))
+		if err != nil {
+			return nil, err
+		}
+		if rt.Owner.Settings.IsPlayground {
+			if err := CheckPlaygroundLimits(rt.Owner, path, NoLimit); err != nil {
+				return nil, err
+			}
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		root := filepath.Dir(path)
+		if info.IsDir() {
+			files = append(files, archiveFile{Path: path, Name: archiveName(root, path),
+				Info: info})
+			if files, err = appendArchiveDir(files, root, path, opts); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		ok, err := opts.match(info.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			files = append(files, archiveFile{Path: path, Name: info.Name(), Info: info})
+		}
+	}
+	return files, nil
+}
+
+// createArchive creates the archive file and checks the limits of the playground
+func createArchive(rt *Runtime, dest string, files []archiveFile) (*os.File, error) {
+	if rt.Owner.Settings.IsPlayground {
+		var size int64
+		for _, file := range files {
+			size += file.Info.Size()
+		}
+		if err := CheckPlaygroundLimits(rt.Owner, dest, size); err != nil {
+			return nil, err
+		}
+	}
+	return os.Create(dest)
+}
+
+// copyFile writes the contents of the file
+func copyFile(out io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(out, f)
+	return err
+}
+
+// extractPath returns the path of the extracted file and prevents zip slip
+func extractPath(rt *Runtime, dir, name string, size int64) (string, error) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if path != dir && !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return ``, fmt.Errorf(ErrorText(ErrArchivePath), name)
+	}
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, path, size); err != nil {
+			return ``, err
+		}
+	}
+	return path, nil
+}
+
+// extractFile writes the file with its parent directories
+func extractFile(path string, mode os.FileMode, in io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	if mode&0777 == 0 {
+		mode = 0644
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode&0777)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, in); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// extractDir returns the absolute path of the destination directory
+func extractDir(rt *Runtime, dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ``, err
+	}
+	if rt.Owner.Settings.IsPlayground {
+		if err = CheckPlaygroundLimits(rt.Owner, dir, NoLimit); err != nil {
+			return ``, err
+		}
+	}
+	return dir, os.MkdirAll(dir, os.ModePerm)
+}
+
+func writeZip(rt *Runtime, dest string, list *core.Array, opts *archiveOptions) error {
+	defer opts.close()
+	files, err := archiveFiles(rt, list, opts)
+	if err != nil {
+		return err
+	}
+	f, err := createArchive(rt, dest, files)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := zip.NewWriter(f)
+	for _, file := range files {
+		header, err := zip.FileInfoHeader(file.Info)
+		if err != nil {
+			return err
+		}
+		header.Name = file.Name
+		if file.Info.IsDir() {
+			header.Name += `/`
+		} else {
+			header.Method = zip.Deflate
+		}
+		out, err := writer.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if file.Info.IsDir() {
+			continue
+		}
+		if err = copyFile(out, file.Path); err != nil {
+			return err
+		}
+		if err = opts.progress(file.Name, file.Info.Size()); err != nil {
+			return err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func readZip(rt *Runtime, src, dir string, opts *archiveOptions) error {
+	defer opts.close()
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, src, NoLimit); err != nil {
+			return err
+		}
+	}
+	dir, err := extractDir(rt, dir)
+	if err != nil {
+		return err
+	}
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		info := file.FileInfo()
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			continue
+		}
+		if ok, err := opts.match(filepath.Base(file.Name)); err != nil {
+			return err
+		} else if !ok && !info.IsDir() {
+			continue
+		}
+		path, err := extractPath(rt, dir, file.Name, info.Size())
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if err = os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+		in, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = extractFile(path, info.Mode(), in)
+		in.Close()
+		if err != nil {
+			return err
+		}
+		if err = opts.progress(file.Name, info.Size()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isGzipName returns true if the name has .gz or .tgz extension
+func isGzipName(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == `.gz` || ext == `.tgz`
+}
+
+func writeTar(rt *Runtime, dest string, list *core.Array, opts *archiveOptions) error {
+	var zipper *gzip.Writer
+	defer opts.close()
+	files, err := archiveFiles(rt, list, opts)
+	if err != nil {
+		return err
+	}
+	f, err := createArchive(rt, dest, files)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var out io.Writer = f
+	if isGzipName(dest) {
+		zipper = gzip.NewWriter(f)
+		out = zipper
+	}
+	writer := tar.NewWriter(out)
+	for _, file := range files {
+		header, err := tar.FileInfoHeader(file.Info, ``)
+		if err != nil {
+			return err
+		}
+		header.Name = file.Name
+		if file.Info.IsDir() {
+			header.Name += `/`
+		}
+		if err = writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if file.Info.IsDir() {
+			continue
+		}
+		if err = copyFile(writer, file.Path); err != nil {
+			return err
+		}
+		if err = opts.progress(file.Name, file.Info.Size()); err != nil {
+			return err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	if zipper != nil {
+		if err = zipper.Close(); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+func readTar(rt *Runtime, src, dir string, opts *archiveOptions) error {
+	defer opts.close()
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, src, NoLimit); err != nil {
+			return err
+		}
+	}
+	dir, err := extractDir(rt, dir)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	in := bufio.NewReader(f)
+	var input io.Reader = in
+	if magic, err := in.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zipper, err := gzip.NewReader(in)
+		if err != nil {
+			return err
+		}
+		defer zipper.Close()
+		input = zipper
+	}
+	reader := tar.NewReader(input)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		isDir := header.Typeflag == tar.TypeDir
+		if !isDir && header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if ok, err := opts.match(filepath.Base(header.Name)); err != nil {
+			return err
+		} else if !ok && !isDir {
+			continue
+		}
+		path, err := extractPath(rt, dir, header.Name, header.Size)
+		if err != nil {
+			return err
+		}
+		if isDir {
+			if err = os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = extractFile(path, os.FileMode(header.Mode), reader); err != nil {
+			return err
+		}
+		if err = opts.progress(header.Name, header.Size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ZipºStrArr creates zip archive with the files and the directories
+func ZipºStrArr(rt *Runtime, dest string, files *core.Array) error {
+	return ZipºStrArrIntStrFn(rt, dest, files, Recursive, ``, nil)
+}
+
+// ZipºStrArrIntStr creates zip archive with the files which match the pattern
+func ZipºStrArrIntStr(rt *Runtime, dest string, files *core.Array, flags int64,
+	pattern string) error {
+	return ZipºStrArrIntStrFn(rt, dest, files, flags, pattern, nil)
+}
+
+// ZipºStrArrIntStrFn creates zip archive and calls fn(str name, int size) for each file
+func ZipºStrArrIntStrFn(rt *Runtime, dest string, files *core.Array, flags int64,
+	pattern string, fn *Fn) error {
+	opts, err := newArchiveOptions(rt, flags, pattern, fn)
+	if err != nil {
+		return err
+	}
+	return writeZip(rt, dest, files, opts)
+}
+
+// UnZipºStrStr extracts zip archive to the directory
+func UnZipºStrStr(rt *Runtime, src, dir string) error {
+	return UnZipºStrStrIntStrFn(rt, src, dir, 0, ``, nil)
+}
+
+// UnZipºStrStrIntStr extracts the files which match the pattern
+func UnZipºStrStrIntStr(rt *Runtime, src, dir string, flags int64, pattern string) error {
+	return UnZipºStrStrIntStrFn(rt, src, dir, flags, pattern, nil)
+}
+
+// UnZipºStrStrIntStrFn extracts zip archive and calls fn(str name, int size) for each file
+func UnZipºStrStrIntStrFn(rt *Runtime, src, dir string, flags int64, pattern string,
+	fn *Fn) error {
+	opts, err := newArchiveOptions(rt, flags, pattern, fn)
+	if err != nil {
+		return err
+	}
+	return readZip(rt, src, dir, opts)
+}
+
+// TarºStrArr creates tar archive. If dest has .gz or .tgz extension then it is compressed
+func TarºStrArr(rt *Runtime, dest string, files *core.Array) error {
+	return TarºStrArrIntStrFn(rt, dest, files, Recursive, ``, nil)
+}
+
+// TarºStrArrIntStr creates tar archive with the files which match the pattern
+func TarºStrArrIntStr(rt *Runtime, dest string, files *core.Array, flags int64,
+	pattern string) error {
+	return TarºStrArrIntStrFn(rt, dest, files, flags, pattern, nil)
+}
+
+// TarºStrArrIntStrFn creates tar archive and calls fn(str name, int size) for each file
+func TarºStrArrIntStrFn(rt *Runtime, dest string, files *core.Array, flags int64,
+	pattern string, fn *Fn) error {
+	opts, err := newArchiveOptions(rt, flags, pattern, fn)
+	if err != nil {
+		return err
+	}
+	return writeTar(rt, dest, files, opts)
+}
+
+// UnTarºStrStr extracts tar or tar.gz archive to the directory
+func UnTarºStrStr(rt *Runtime, src, dir string) error {
+	return UnTarºStrStrIntStrFn(rt, src, dir, 0, ``, nil)
+}
+
+// UnTarºStrStrIntStr extracts the files which match the pattern
+func UnTarºStrStrIntStr(rt *Runtime, src, dir string, flags int64, pattern string) error {
+	return UnTarºStrStrIntStrFn(rt, src, dir, flags, pattern, nil)
+}
+
+// UnTarºStrStrIntStrFn extracts tar archive and calls fn(str name, int size) for each file
+func UnTarºStrStrIntStrFn(rt *Runtime, src, dir string, flags int64, pattern string,
+	fn *Fn) error {
+	opts, err := newArchiveOptions(rt, flags, pattern, fn)
+	if err != nil {
+		return err
+	}
+	return readTar(rt, src, dir, opts)
+}
+
+// GzipºBuf compresses the buffer
+func GzipºBuf(buf *core.Buffer) (*core.Buffer, error) {
+	return CompressºBufStr(buf, `gzip`)
+}
+
+// UnGzipºBuf decompresses the buffer
+func UnGzipºBuf(rt *Runtime, buf *core.Buffer) (*core.Buffer, error) {
+	return DecompressºBufStr(rt, buf, `gzip`)
+}
+
+// GzipºStrStr compresses the file
+func GzipºStrStr(rt *Runtime, src, dest string) error {
+	return CompressFileºStrStrStr(rt, src, dest, `gzip`)
+}
+
+// UnGzipºStrStr decompresses the file
+func UnGzipºStrStr(rt *Runtime, src, dest string) error {
+	return DecompressFileºStrStrStr(rt, src, dest, `gzip`)
+}
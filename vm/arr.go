@@ -6,6 +6,7 @@ package vm
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -73,3 +74,166 @@ func SortºArr(value *core.Array) *core.Array {
 	sort.Sort(value)
 	return value
 }
+
+// fnValue converts the result of fn to the value of the stack
+func fnValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return int64(1)
+		}
+		return int64(0)
+	case rune:
+		return int64(v)
+	}
+	return value
+}
+
+// arrWorker returns the worker thread for calling fn from embedded functions
+func arrWorker(rt *Runtime, fn *Fn) (*Runtime, error) {
+	if fn == nil || fn.Func == 0 {
+		return nil, fmt.Errorf(ErrorText(ErrFnEmpty))
+	}
+	worker := rt.Owner.newThread(ThWork)
+	if worker == nil {
+		return nil, fmt.Errorf(ErrorText(ErrThreadClosed))
+	}
+	return worker, nil
+}
+
+// ContainsºArr returns true if the array contains the value
+func ContainsºArr(arr *core.Array, value interface{}) int64 {
+	if IndexOfºArr(arr, value) >= 0 {
+		return 1
+	}
+	return 0
+}
+
+// DeleteºArr deletes the item of the array
+func DeleteºArr(arr *core.Array, index int64) (*core.Array, error) {
+	if index < 0 || index >= int64(len(arr.Data)) {
+		return nil, fmt.Errorf(ErrorText(ErrIndexOut))
+	}
+	arr.Data = append(arr.Data[:index], arr.Data[index+1:]...)
+	return arr, nil
+}
+
+// FilterºArrFn returns the items of the array for which fn returns true
+func FilterºArrFn(rt *Runtime, arr *core.Array, fn *Fn) (*core.Array, error) {
+	worker, err := arrWorker(rt, fn)
+	if err != nil {
+		return nil, err
+	}
+	defer worker.closeWorker()
+	ret := core.NewArray()
+	for _, item := range arr.Data {
+		result, err := worker.callFn(fn, item)
+		if err != nil {
+			return nil, err
+		}
+		if result.(bool) {
+			var ptr interface{}
+			CopyVar(rt, &ptr, item)
+			ret.Data = append(ret.Data, ptr)
+		}
+	}
+	return ret, nil
+}
+
+// IndexOfºArr returns the index of the first item which is equal to the value or -1
+func IndexOfºArr(arr *core.Array, value interface{}) int64 {
+	for i, item := range arr.Data {
+		if reflect.DeepEqual(item, value) {
+			return int64(i)
+		}
+	}
+	return -1
+}
+
+// InsertºArr inserts the copy of the value into the array at the specified index
+func InsertºArr(rt *Runtime, arr *core.Array, index int64, value interface{}) (*core.Array,
+	error) {
+	if index < 0 || index > int64(len(arr.Data)) {
+		return nil, fmt.Errorf(ErrorText(ErrIndexOut))
+	}
+	var ptr interface{}
+	CopyVar(rt, &ptr, value)
+	arr.Data = append(arr.Data, nil)
+	copy(arr.Data[index+1:], arr.Data[index:])
+	arr.Data[index] = ptr
+	return arr, nil
+}
+
+// MapºArrFn returns the array of the results of fn for each item of the array
+func MapºArrFn(rt *Runtime, arr *core.Array, fn *Fn) (*core.Array, error) {
+	worker, err := arrWorker(rt, fn)
+	if err != nil {
+		return nil, err
+	}
+	defer worker.closeWorker()
+	ret := core.NewArray()
+	ret.Data = make([]interface{}, len(arr.Data))
+	for i, item := range arr.Data {
+		result, err := worker.callFn(fn, item)
+		if err != nil {
+			return nil, err
+		}
+		ret.Data[i] = fnValue(result)
+	}
+	return ret, nil
+}
+
+// ReduceºArrFn calls fn for each item of the array with the result of the previous call
+func ReduceºArrFn(rt *Runtime, arr *core.Array, fn *Fn, init interface{}) (interface{}, error) {
+	worker, err := arrWorker(rt, fn)
+	if err != nil {
+		return nil, err
+	}
+	defer worker.closeWorker()
+	acc := init
+	for _, item := range arr.Data {
+		result, err := worker.callFn(fn, acc, item)
+		if err != nil {
+			return nil, err
+		}
+		acc = fnValue(result)
+	}
+	return acc, nil
+}
+
+// SortByºArrFn sorts the array with fn which returns true if the first item is less
+// than the second one
+func SortByºArrFn(rt *Runtime, arr *core.Array, fn *Fn) (*core.Array, error) {
+	worker, err := arrWorker(rt, fn)
+	if err != nil {
+		return nil, err
+	}
+	defer worker.closeWorker()
+	sort.SliceStable(arr.Data, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		var result interface{}
+		if result, err = worker.callFn(fn, arr.Data[i], arr.Data[j]); err != nil {
+			return false
+		}
+		return result.(bool)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
+// UniqueºArr returns the array without the duplicate items
+func UniqueºArr(rt *Runtime, arr *core.Array) *core.Array {
+	ret := core.NewArray()
+	for _, item := range arr.Data {
+		if IndexOfºArr(ret, item) < 0 {
+			var ptr interface{}
+			CopyVar(rt, &ptr, item)
+			ret.Data = append(ret.Data, ptr)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,191 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gentee/gentee/core"
+)
+
+const (
+	// EvStart means that the thread has been started
+	EvStart = iota
+	// EvFinish means that the thread has been finished
+	EvFinish
+	// EvError means that the thread has been finished with an error
+	EvError
+)
+
+// EventsSize is the buffer size of the events channel. The events are dropped if it is full
+const EventsSize = 256
+
+// ThreadInfo contains information about the thread for the host
+type ThreadInfo struct {
+	ID     int64
+	Status int
+	Path   string // the current source file
+	Line   int64  // the current line
+	Start  time.Time
+}
+
+// ThreadEvent is the event of the thread
+type ThreadEvent struct {
+	Type int
+	ID   int64
+	Err  error
+}
+
+// Handle is used for the management of the running script
+type Handle struct {
+	Events <-chan ThreadEvent
+
+	vm     *VM
+	done   chan struct{}
+	result interface{}
+	err    error
+}
+
+// event sends the event of the thread if the script has been started by RunAsync
+func (vm *VM) event(rt *Runtime, err error) {
+	if vm.Events == nil {
+		return
+	}
+	evType := EvStart
+	vm.ThreadMutex.RLock()
+	status := rt.Thread.Status
+	vm.ThreadMutex.RUnlock()
+	if err != nil && status != ThClosed {
+		evType = EvError
+	} else if status >= ThFinished {
+		evType = EvFinish
+	}
+	select {
+	case vm.Events <- ThreadEvent{Type: evType, ID: rt.ThreadID, Err: err}:
+	default:
+	}
+}
+
+// setPos saves the current offset of the bytecode for ThreadInfo
+func (rt *Runtime) setPos(offset int64) {
+	atomic.StoreInt64(&rt.Pos, offset)
+}
+
+// RunAsync starts the bytecode in the background and returns the handle of the script
+func RunAsync(exec *core.Exec, settings Settings) (*Handle, error) {
+	vm, err := newVM(exec, settings)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan ThreadEvent, EventsSize)
+	vm.Events = events
+	h := &Handle{
+		Events: events,
+		vm:     vm,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		h.result, h.err = vm.run()
+		close(h.done)
+	}()
+	return h, nil
+}
+
+// Done returns the channel which is closed when the script finishes
+func (h *Handle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait waits for the finish of the script and returns its result
+func (h *Handle) Wait() (interface{}, error) {
+	<-h.done
+	return h.result, h.err
+}
+
+// Threads returns information about all threads of the script
+func (h *Handle) Threads() []ThreadInfo {
+	vm := h.vm
+	vm.ThreadMutex.RLock()
+	defer vm.ThreadMutex.RUnlock()
+	ret := make([]ThreadInfo, len(vm.Runtimes))
+	for i, rt := range vm.Runtimes {
+		ret[i] = ThreadInfo{
+			ID:     rt.ThreadID,
+			Status: int(rt.Thread.Status),
+			Start:  rt.Thread.Start,
+		}
+		// the position of the command or the nearest previous position
+		offset := int32(atomic.LoadInt64(&rt.Pos))
+		var cur *core.CodePos
+		for k, ipos := range vm.Exec.Pos {
+			if ipos.Offset <= offset && (cur == nil || ipos.Offset > cur.Offset) {
+				cur = &vm.Exec.Pos[k]
+			}
+		}
+		if cur != nil {
+			ret[i].Path = vm.Exec.Strings[cur.Path]
+			ret[i].Line = int64(cur.Line)
+		}
+	}
+	return ret
+}
+
+func (h *Handle) main() (*Runtime, error) {
+	h.vm.ThreadMutex.RLock()
+	defer h.vm.ThreadMutex.RUnlock()
+	if len(h.vm.Runtimes) == 0 {
+		return nil, fmt.Errorf(ErrorText(ErrThreadIndex))
+	}
+	return h.vm.Runtimes[0], nil
+}
+
+// Suspend suspends the thread. The zero id suspends the whole script
+func (h *Handle) Suspend(id int64) error {
+	if id == 0 {
+		h.vm.suspend()
+		return nil
+	}
+	rt, err := h.main()
+	if err != nil {
+		return err
+	}
+	return suspendºThread(rt, id)
+}
+
+// Resume resumes the thread. The zero id resumes the whole script
+func (h *Handle) Resume(id int64) error {
+	if id == 0 {
+		h.vm.resume()
+		return nil
+	}
+	rt, err := h.main()
+	if err != nil {
+		return err
+	}
+	return resumeºThread(rt, id)
+}
+
+// Terminate closes the thread. The zero id terminates the whole script
+func (h *Handle) Terminate(id int64) error {
+	rt, err := h.main()
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		h.vm.ThreadMutex.RLock()
+		if rt.Thread.Status < ThFinished {
+			select {
+			case h.vm.ChError <- fmt.Errorf(ErrorText(ErrTerminated)):
+			default:
+			}
+		}
+		h.vm.ThreadMutex.RUnlock()
+		h.vm.resume() // if it has been suspended
+		return nil
+	}
+	return terminateºThread(rt, id)
+}
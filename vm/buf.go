@@ -5,11 +5,20 @@
 package vm
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 
+	"github.com/andybalholm/brotli"
 	"github.com/gentee/gentee/core"
+	"github.com/klauspost/compress/zstd"
 )
 
 // AddºBufBuf adds two buffers
@@ -68,6 +77,153 @@ func bufºStr(value string) *core.Buffer {
 	return b
 }
 
+// compressWriter returns the writer which compresses data with the algorithm
+func compressWriter(out io.Writer, algo string) (io.WriteCloser, error) {
+	switch algo {
+	case `gzip`:
+		return gzip.NewWriter(out), nil
+	case `zlib`:
+		return zlib.NewWriter(out), nil
+	case `deflate`:
+		return flate.NewWriter(out, flate.DefaultCompression)
+	case `zstd`:
+		return zstd.NewWriter(out)
+	case `brotli`, `br`:
+		return brotli.NewWriter(out), nil
+	}
+	return nil, fmt.Errorf(ErrorText(ErrCompress), algo)
+}
+
+// decompressReader returns the reader which decompresses data with the algorithm
+func decompressReader(in io.Reader, algo string) (io.ReadCloser, error) {
+	switch algo {
+	case `gzip`:
+		return gzip.NewReader(in)
+	case `zlib`:
+		return zlib.NewReader(in)
+	case `deflate`:
+		return flate.NewReader(in), nil
+	case `zstd`:
+		decoder, err := zstd.NewReader(in)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	case `brotli`, `br`:
+		return ioutil.NopCloser(brotli.NewReader(in)), nil
+	}
+	return nil, fmt.Errorf(ErrorText(ErrCompress), algo)
+}
+
+// playgroundWriter checks the limits of the playground while writing the file
+type playgroundWriter struct {
+	rt       *Runtime
+	filename string
+	out      io.Writer
+}
+
+func (writer *playgroundWriter) Write(data []byte) (int, error) {
+	if writer.rt.Owner.Settings.IsPlayground {
+		err := CheckPlaygroundLimits(writer.rt.Owner, writer.filename, int64(len(data)))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return writer.out.Write(data)
+}
+
+// copyStream copies the source file to the destination file through fn
+func copyStream(rt *Runtime, src, dest string, fn func(io.Reader, io.Writer) error) error {
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, src, NoLimit); err != nil {
+			return err
+		}
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if err = fn(in, &playgroundWriter{rt: rt, filename: dest, out: out}); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// CompressºBufStr compresses buf with gzip, zlib, deflate, zstd or brotli algorithm
+func CompressºBufStr(buf *core.Buffer, algo string) (*core.Buffer, error) {
+	var out bytes.Buffer
+	writer, err := compressWriter(&out, algo)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(buf.Data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	ret := core.NewBuffer()
+	ret.Data = out.Bytes()
+	return ret, nil
+}
+
+// CompressFileºStrStrStr compresses the file to the destination file
+func CompressFileºStrStrStr(rt *Runtime, src, dest, algo string) error {
+	return copyStream(rt, src, dest, func(in io.Reader, out io.Writer) error {
+		writer, err := compressWriter(out, algo)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(writer, in); err != nil {
+			writer.Close()
+			return err
+		}
+		return writer.Close()
+	})
+}
+
+// DecompressºBufStr decompresses buf with gzip, zlib, deflate, zstd or brotli algorithm.
+// In Playground mode the size of the result is limited by the file size limit
+func DecompressºBufStr(rt *Runtime, buf *core.Buffer, algo string) (*core.Buffer, error) {
+	reader, err := decompressReader(bytes.NewReader(buf.Data), algo)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var in io.Reader = reader
+	limit := rt.Owner.Settings.Playground.SizeLimit
+	if rt.Owner.Settings.IsPlayground {
+		in = io.LimitReader(reader, limit+1)
+	}
+	ret := core.NewBuffer()
+	if ret.Data, err = ioutil.ReadAll(in); err != nil {
+		return nil, err
+	}
+	if rt.Owner.Settings.IsPlayground && int64(len(ret.Data)) > limit {
+		return nil, fmt.Errorf(`%s [%d MB]`, ErrorText(ErrPlaySize), limit>>20)
+	}
+	return ret, nil
+}
+
+// DecompressFileºStrStrStr decompresses the file to the destination file
+func DecompressFileºStrStrStr(rt *Runtime, src, dest, algo string) error {
+	return copyStream(rt, src, dest, func(in io.Reader, out io.Writer) error {
+		reader, err := decompressReader(in, algo)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		_, err = io.Copy(out, reader)
+		return err
+	})
+}
+
 // DelºBufIntInt deletes part of the buffer
 func DelºBufIntInt(buf *core.Buffer, off, length int64) (*core.Buffer, error) {
 	size := int64(len(buf.Data))
@@ -5,12 +5,253 @@
 package vm
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
 
 	"github.com/gentee/gentee/core"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 )
 
+// newHash returns the hash function of the algorithm
+func newHash(algo string) (func() hash.Hash, error) {
+	switch algo {
+	case `md5`:
+		return md5.New, nil
+	case `sha1`:
+		return sha1.New, nil
+	case `sha224`:
+		return sha256.New224, nil
+	case `sha256`:
+		return sha256.New, nil
+	case `sha384`:
+		return sha512.New384, nil
+	case `sha512`:
+		return sha512.New, nil
+	case `crc32`:
+		return func() hash.Hash { return crc32.NewIEEE() }, nil
+	}
+	return nil, fmt.Errorf(ErrorText(ErrHashAlgo), algo)
+}
+
+// hashFile returns the hash of the file as a hex string
+func hashFile(algo, filename string) (string, error) {
+	newFunc, err := newHash(algo)
+	if err != nil {
+		return ``, err
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return ``, err
+	}
+	defer file.Close()
+	hasher := newFunc()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return ``, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// EqualºBufBuf compares two buffers in constant time
+func EqualºBufBuf(left, right *core.Buffer) int64 {
+	return int64(subtle.ConstantTimeCompare(left.Data, right.Data))
+}
+
+// HashºStrBuf returns the hash of the buffer. The algorithm can be md5, sha1, sha224, sha256,
+// sha384, sha512 or crc32
+func HashºStrBuf(algo string, in *core.Buffer) (*core.Buffer, error) {
+	newFunc, err := newHash(algo)
+	if err != nil {
+		return nil, err
+	}
+	hasher := newFunc()
+	hasher.Write(in.Data)
+	out := core.NewBuffer()
+	out.Data = hasher.Sum(nil)
+	return out, nil
+}
+
+// HashFileºStrStr returns the hash of the file as a hex string
+func HashFileºStrStr(rt *Runtime, algo, filename string) (string, error) {
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, filename, NoLimit); err != nil {
+			return ``, err
+		}
+	}
+	return hashFile(algo, filename)
+}
+
+// HmacºStrBufBuf returns HMAC of the data with the key
+func HmacºStrBufBuf(algo string, key, data *core.Buffer) (*core.Buffer, error) {
+	if algo == `crc32` {
+		return nil, fmt.Errorf(ErrorText(ErrHashAlgo), algo)
+	}
+	newFunc, err := newHash(algo)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(newFunc, key.Data)
+	mac.Write(data.Data)
+	out := core.NewBuffer()
+	out.Data = mac.Sum(nil)
+	return out, nil
+}
+
+// newGCM returns AES-GCM cipher for the key of 16, 24 or 32 bytes
+func newGCM(key []byte) (cipher.AEAD, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, fmt.Errorf(ErrorText(ErrCryptoKey), len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns the random nonce followed by the encrypted data
+func encrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(data)+gcm.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+// decrypt decrypts the data which has been encrypted by encrypt
+func decrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf(ErrorText(ErrDecrypt))
+	}
+	size := gcm.NonceSize()
+	ret, err := gcm.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return nil, fmt.Errorf(ErrorText(ErrDecrypt))
+	}
+	return ret, nil
+}
+
+// cryptFile reads the source file, converts it and writes the result to the destination file
+func cryptFile(rt *Runtime, src, dest string, crypt func([]byte) ([]byte, error)) error {
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, src, NoLimit); err != nil {
+			return err
+		}
+	}
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if data, err = crypt(data); err != nil {
+		return err
+	}
+	if rt.Owner.Settings.IsPlayground {
+		if err = CheckPlaygroundLimits(rt.Owner, dest, int64(len(data))); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(dest, data, 0600)
+}
+
+// DecryptºBufBuf decrypts the data with AES-GCM. The data must start with the nonce
+func DecryptºBufBuf(key, data *core.Buffer) (*core.Buffer, error) {
+	var err error
+	out := core.NewBuffer()
+	if out.Data, err = decrypt(key.Data, data.Data); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecryptFileºStrStrBuf decrypts the source file to the destination file
+func DecryptFileºStrStrBuf(rt *Runtime, src, dest string, key *core.Buffer) error {
+	return cryptFile(rt, src, dest, func(data []byte) ([]byte, error) {
+		return decrypt(key.Data, data)
+	})
+}
+
+// DeriveKeyºStrStrBufInt derives the key of the specified length from the password.
+// The algorithm can be pbkdf2, scrypt or argon2id. The salt must not be empty
+func DeriveKeyºStrStrBufInt(algo, password string, salt *core.Buffer, size int64) (
+	*core.Buffer, error) {
+	var err error
+	if size <= 0 || size > 1024 || len(salt.Data) == 0 {
+		return nil, fmt.Errorf(ErrorText(ErrInvalidParam))
+	}
+	out := core.NewBuffer()
+	switch algo {
+	case `pbkdf2`:
+		out.Data = pbkdf2.Key([]byte(password), salt.Data, 600000, int(size), sha256.New)
+	case `scrypt`:
+		out.Data, err = scrypt.Key([]byte(password), salt.Data, 32768, 8, 1, int(size))
+	case `argon2id`:
+		out.Data = argon2.IDKey([]byte(password), salt.Data, 1, 64*1024, 4, uint32(size))
+	default:
+		return nil, fmt.Errorf(ErrorText(ErrKeyAlgo), algo)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EncryptºBufBuf encrypts the data with AES-GCM. The random nonce is put at the beginning
+// of the result
+func EncryptºBufBuf(key, data *core.Buffer) (*core.Buffer, error) {
+	var err error
+	out := core.NewBuffer()
+	if out.Data, err = encrypt(key.Data, data.Data); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EncryptFileºStrStrBuf encrypts the source file to the destination file
+func EncryptFileºStrStrBuf(rt *Runtime, src, dest string, key *core.Buffer) error {
+	return cryptFile(rt, src, dest, func(data []byte) ([]byte, error) {
+		return encrypt(key.Data, data)
+	})
+}
+
+// RandomBytesºInt returns the buffer with cryptographically secure random bytes.
+// The size can't be greater than 1 MiB
+func RandomBytesºInt(size int64) (*core.Buffer, error) {
+	if size < 0 || size > 1<<20 {
+		return nil, fmt.Errorf(ErrorText(ErrInvalidParam))
+	}
+	out := core.NewBuffer()
+	out.Data = make([]byte, size)
+	if _, err := rand.Read(out.Data); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func md5Hash(in []byte) (out *core.Buffer) {
 	out = core.NewBuffer()
 	md5Hash := md5.Sum(in)
@@ -0,0 +1,257 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gentee/gentee/core"
+)
+
+const bom = "\ufeff"
+
+// csvOptions contains the settings of CSV reading and writing
+type csvOptions struct {
+	Delim   rune // the delimiter of the fields
+	Comment rune // the comment character
+	Trim    bool // leading white space in a field is ignored
+	Lazy    bool // a quote may appear in an unquoted field
+	Quote   bool // all fields are quoted
+	CRLF    bool // \r\n is used as the line terminator
+	BOM     bool // the output starts with the byte order mark
+}
+
+// parseCSVOptions parses the options like `delim=; comment=# trim lazy quote crlf bom`
+func parseCSVOptions(opts string) (*csvOptions, error) {
+	ret := &csvOptions{Delim: ','}
+	for _, opt := range strings.Split(opts, ` `) {
+		if len(opt) == 0 {
+			continue
+		}
+		name, value := opt, ``
+		if eq := strings.IndexRune(opt, '='); eq > 0 {
+			name, value = opt[:eq], opt[eq+1:]
+			if value == `\t` {
+				value = "\t"
+			}
+			if utf8.RuneCountInString(value) != 1 {
+				return nil, fmt.Errorf(ErrorText(ErrCSVOption), opt)
+			}
+		}
+		char, _ := utf8.DecodeRuneInString(value)
+		switch name {
+		case `delim`:
+			ret.Delim = char
+		case `comment`:
+			ret.Comment = char
+		case `trim`:
+			ret.Trim = true
+		case `lazy`:
+			ret.Lazy = true
+		case `quote`:
+			ret.Quote = true
+		case `crlf`:
+			ret.CRLF = true
+		case `bom`:
+			ret.BOM = true
+		default:
+			return nil, fmt.Errorf(ErrorText(ErrCSVOption), opt)
+		}
+		if (len(value) > 0) != (name == `delim` || name == `comment`) {
+			return nil, fmt.Errorf(ErrorText(ErrCSVOption), opt)
+		}
+	}
+	return ret, nil
+}
+
+// readCSV returns the records of CSV data
+func readCSV(data, opts string) ([][]string, error) {
+	options, err := parseCSVOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(data, bom)))
+	reader.Comma = options.Delim
+	reader.Comment = options.Comment
+	reader.TrimLeadingSpace = options.Trim
+	reader.LazyQuotes = options.Lazy
+	return reader.ReadAll()
+}
+
+// csvToArr converts the records to arr.arr.str
+func csvToArr(records [][]string) *core.Array {
+	ret := core.NewArray()
+	ret.Data = make([]interface{}, len(records))
+	for i, record := range records {
+		row := core.NewArray()
+		row.Data = make([]interface{}, len(record))
+		for j, field := range record {
+			row.Data[j] = field
+		}
+		ret.Data[i] = row
+	}
+	return ret
+}
+
+// csvToMaps converts the records to arr.map.str with the keys from the header row
+func csvToMaps(records [][]string, ret *core.Array) *core.Array {
+	ret.Data = ret.Data[:0]
+	if len(records) == 0 {
+		return ret
+	}
+	header := records[0]
+	for _, record := range records[1:] {
+		row := core.NewMap()
+		for j, key := range header {
+			var field string
+			if j < len(record) {
+				field = record[j]
+			}
+			if _, ok := row.Data[key]; !ok {
+				row.Keys = append(row.Keys, key)
+			}
+			row.Data[key] = field
+		}
+		ret.Data = append(ret.Data, row)
+	}
+	return ret
+}
+
+// writeCSV writes arr.arr.str as CSV data
+func writeCSV(out io.Writer, arr *core.Array, opts string) error {
+	options, err := parseCSVOptions(opts)
+	if err != nil {
+		return err
+	}
+	if options.BOM {
+		if _, err = io.WriteString(out, bom); err != nil {
+			return err
+		}
+	}
+	writer := csv.NewWriter(out)
+	writer.Comma = options.Delim
+	writer.UseCRLF = options.CRLF
+	for _, item := range arr.Data {
+		row := item.(*core.Array)
+		record := make([]string, len(row.Data))
+		for j, field := range row.Data {
+			record[j] = field.(string)
+		}
+		if options.Quote {
+			_, err = io.WriteString(out, quotedCSV(record, options))
+		} else {
+			err = writer.Write(record)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// quotedCSV returns the CSV line with all quoted fields
+func quotedCSV(record []string, options *csvOptions) string {
+	fields := make([]string, len(record))
+	for i, field := range record {
+		fields[i] = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+	}
+	if options.CRLF {
+		return strings.Join(fields, string(options.Delim)) + "\r\n"
+	}
+	return strings.Join(fields, string(options.Delim)) + "\n"
+}
+
+// CSVºArr converts arr.arr.str to CSV string
+func CSVºArr(arr *core.Array) (string, error) {
+	return CSVºArrStr(arr, ``)
+}
+
+// CSVºArrStr converts arr.arr.str to CSV string with the options
+func CSVºArrStr(arr *core.Array, opts string) (string, error) {
+	var out bytes.Buffer
+	if err := writeCSV(&out, arr, opts); err != nil {
+		return ``, err
+	}
+	return out.String(), nil
+}
+
+// ParseCSVºStr parses CSV string to arr.arr.str
+func ParseCSVºStr(data string) (*core.Array, error) {
+	return ParseCSVºStrStr(data, ``)
+}
+
+// ParseCSVºStrStr parses CSV string with the options to arr.arr.str
+func ParseCSVºStrStr(data, opts string) (*core.Array, error) {
+	records, err := readCSV(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	return csvToArr(records), nil
+}
+
+// ParseCSVºStrStrArr parses CSV string with the options to arr.map.str. The first row
+// contains the keys
+func ParseCSVºStrStrArr(data, opts string, ret *core.Array) (*core.Array, error) {
+	records, err := readCSV(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	return csvToMaps(records, ret), nil
+}
+
+// readCSVFile reads the CSV file
+func readCSVFile(rt *Runtime, filename, opts string) ([][]string, error) {
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, filename, NoLimit); err != nil {
+			return nil, err
+		}
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return readCSV(string(data), opts)
+}
+
+// ReadCSVºStrStr reads CSV file to arr.arr.str
+func ReadCSVºStrStr(rt *Runtime, filename, opts string) (*core.Array, error) {
+	records, err := readCSVFile(rt, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	return csvToArr(records), nil
+}
+
+// ReadCSVºStrStrArr reads CSV file to arr.map.str. The first row contains the keys
+func ReadCSVºStrStrArr(rt *Runtime, filename, opts string, ret *core.Array) (*core.Array,
+	error) {
+	records, err := readCSVFile(rt, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	return csvToMaps(records, ret), nil
+}
+
+// WriteCSVºStrArrStr writes arr.arr.str to CSV file
+func WriteCSVºStrArrStr(rt *Runtime, filename string, arr *core.Array, opts string) error {
+	var out bytes.Buffer
+	if err := writeCSV(&out, arr, opts); err != nil {
+		return err
+	}
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, filename, int64(out.Len())); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(filename, out.Bytes(), os.ModePerm)
+}
@@ -6,7 +6,10 @@ package vm
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/gentee/gentee/core"
@@ -98,3 +101,487 @@ func Json(obj *core.Obj) (ret string, err error) {
 	}
 	return
 }
+
+var xmlEscape = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`, `"`, `&quot;`)
+
+// newXmlElement returns the map of XML element with name, attrs, text and children keys
+func newXmlElement(name string) *core.Map {
+	ret := core.NewMap()
+	for _, item := range []struct {
+		key   string
+		value interface{}
+	}{
+		{`name`, name},
+		{`attrs`, core.NewMap()},
+		{`text`, ``},
+		{`children`, core.NewArray()},
+	} {
+		obj := core.NewObj()
+		obj.Data = item.value
+		ret.Keys = append(ret.Keys, item.key)
+		ret.Data[item.key] = obj
+	}
+	return ret
+}
+
+func xmlName(name xml.Name) string {
+	if len(name.Space) > 0 {
+		return name.Space + `:` + name.Local
+	}
+	return name.Local
+}
+
+func xmlField(element *core.Map, key string) *core.Obj {
+	return element.Data[key].(*core.Obj)
+}
+
+func xmlChildren(element *core.Map) []*core.Map {
+	items := xmlField(element, `children`).Data.(*core.Array).Data
+	ret := make([]*core.Map, len(items))
+	for i, item := range items {
+		ret[i] = item.(*core.Obj).Data.(*core.Map)
+	}
+	return ret
+}
+
+// XmlToObj converts XML to object. Each element is a map with name, attrs, text and
+// children keys
+func XmlToObj(input string) (*core.Obj, error) {
+	var (
+		root  *core.Obj
+		stack []*core.Map
+	)
+	d := xml.NewDecoder(strings.NewReader(input))
+	xmlError := func(msg string, args ...interface{}) error {
+		line := strings.Count(input[:d.InputOffset()], "\n") + 1
+		return fmt.Errorf(ErrorText(ErrParseLine), `xml`, line, fmt.Sprintf(msg, args...))
+	}
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if synErr, ok := err.(*xml.SyntaxError); ok {
+				return nil, fmt.Errorf(ErrorText(ErrParseLine), `xml`, synErr.Line, synErr.Msg)
+			}
+			return nil, xmlError(err.Error())
+		}
+		switch v := token.(type) {
+		case xml.StartElement:
+			element := newXmlElement(xmlName(v.Name))
+			attrs := xmlField(element, `attrs`).Data.(*core.Map)
+			for _, attr := range v.Attr {
+				name := xmlName(attr.Name)
+				if _, ok := attrs.Data[name]; ok {
+					return nil, xmlError(`attribute %s redefined`, name)
+				}
+				obj := core.NewObj()
+				obj.Data = attr.Value
+				attrs.Keys = append(attrs.Keys, name)
+				attrs.Data[name] = obj
+			}
+			obj := core.NewObj()
+			obj.Data = element
+			if len(stack) > 0 {
+				children := xmlField(stack[len(stack)-1], `children`).Data.(*core.Array)
+				children.Data = append(children.Data, obj)
+			} else if root != nil {
+				return nil, xmlError(`multiple root elements`)
+			} else {
+				root = obj
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			name := xmlName(v.Name)
+			if len(stack) == 0 {
+				return nil, xmlError(`unexpected end element </%s>`, name)
+			}
+			element := stack[len(stack)-1]
+			if start := xmlField(element, `name`).Data.(string); start != name {
+				return nil, xmlError(`element <%s> closed by </%s>`, start, name)
+			}
+			text := xmlField(element, `text`)
+			text.Data = strings.TrimSpace(text.Data.(string))
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				text := xmlField(stack[len(stack)-1], `text`)
+				text.Data = text.Data.(string) + string(v)
+			} else if len(strings.TrimSpace(string(v))) > 0 {
+				return nil, xmlError(`text outside of the root element`)
+			}
+		}
+	}
+	if len(stack) > 0 {
+		return nil, xmlError(`unexpected EOF`)
+	}
+	if root == nil {
+		return nil, xmlError(`missing root element`)
+	}
+	return root, nil
+}
+
+// xmlValue returns the string value of the scalar field of XML element
+func xmlValue(element *core.Map, key string) (string, bool) {
+	item, ok := element.Data[key]
+	if !ok {
+		return ``, true
+	}
+	switch v := item.(*core.Obj).Data.(type) {
+	case nil:
+		return ``, true
+	case string:
+		return v, true
+	case int64, float64, bool:
+		return fmt.Sprint(v), true
+	}
+	return ``, false
+}
+
+func writeXml(out *strings.Builder, obj *core.Obj, path string) error {
+	element, ok := obj.Data.(*core.Map)
+	if !ok {
+		return fmt.Errorf(ErrorText(ErrXmlObj), path)
+	}
+	name, ok := xmlValue(element, `name`)
+	if !ok || len(name) == 0 {
+		return fmt.Errorf(ErrorText(ErrXmlObj), path)
+	}
+	text, ok := xmlValue(element, `text`)
+	if !ok {
+		return fmt.Errorf(ErrorText(ErrXmlObj), path)
+	}
+	out.WriteString(`<` + name)
+	if item, ok := element.Data[`attrs`]; ok && item.(*core.Obj).Data != nil {
+		attrs, ok := item.(*core.Obj).Data.(*core.Map)
+		if !ok {
+			return fmt.Errorf(ErrorText(ErrXmlObj), path)
+		}
+		for _, key := range attrs.Keys {
+			value, ok := xmlValue(attrs, key)
+			if !ok {
+				return fmt.Errorf(ErrorText(ErrXmlObj), path)
+			}
+			out.WriteString(` ` + key + `="` + xmlEscape.Replace(value) + `"`)
+		}
+	}
+	var children []interface{}
+	if item, ok := element.Data[`children`]; ok && item.(*core.Obj).Data != nil {
+		arr, ok := item.(*core.Obj).Data.(*core.Array)
+		if !ok {
+			return fmt.Errorf(ErrorText(ErrXmlObj), path)
+		}
+		children = arr.Data
+	}
+	if len(text) == 0 && len(children) == 0 {
+		out.WriteString(`/>`)
+		return nil
+	}
+	out.WriteString(`>` + xmlEscape.Replace(text))
+	for i, child := range children {
+		if err := writeXml(out, child.(*core.Obj), fmt.Sprintf(`%s.children[%d]`, path, i)); err != nil {
+			return err
+		}
+	}
+	out.WriteString(`</` + name + `>`)
+	return nil
+}
+
+// Xml converts object to XML
+func Xml(obj *core.Obj) (string, error) {
+	var out strings.Builder
+	if err := writeXml(&out, obj, `obj`); err != nil {
+		return ``, err
+	}
+	return out.String(), nil
+}
+
+// xpathStep is a location step of XPath expression
+type xpathStep struct {
+	Descendant bool     // the step follows //
+	Test       string   // the name of element, *, @attr, @* or text()
+	Preds      []string // the predicates
+}
+
+// splitXPath splits the string by the separator outside of brackets and quotes
+func splitXPath(expr string, sep byte) []string {
+	var (
+		ret   []string
+		quote byte
+		depth int
+	)
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		ch := expr[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case ch == sep && depth == 0:
+			ret = append(ret, expr[start:i])
+			start = i + 1
+		}
+	}
+	return append(ret, expr[start:])
+}
+
+func parseXPath(expr string) ([]xpathStep, error) {
+	var steps []xpathStep
+	errXPath := fmt.Errorf(ErrorText(ErrXPath), expr)
+	path := strings.TrimSpace(expr)
+	relative := !strings.HasPrefix(path, `/`)
+	descendant := relative
+	if !relative {
+		path = path[1:]
+	}
+	for _, item := range splitXPath(path, '/') {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			if descendant {
+				return nil, errXPath
+			}
+			descendant = true
+			continue
+		}
+		step := xpathStep{Descendant: descendant}
+		descendant = false
+		if bracket := strings.IndexByte(item, '['); bracket >= 0 {
+			var ok bool
+			step.Test = strings.TrimSpace(item[:bracket])
+			if step.Preds, ok = xpathPreds(item[bracket:]); !ok {
+				return nil, errXPath
+			}
+		} else {
+			step.Test = item
+		}
+		if len(step.Test) == 0 || !isXPathName(strings.TrimPrefix(step.Test, `@`)) &&
+			step.Test != `text()` {
+			return nil, errXPath
+		}
+		if len(steps) > 0 && isXPathValue(steps[len(steps)-1].Test) {
+			return nil, errXPath
+		}
+		if isXPathValue(step.Test) && len(step.Preds) > 0 {
+			return nil, errXPath
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 || descendant && !relative {
+		return nil, errXPath
+	}
+	if relative {
+		// the relative path is searched in the whole document
+		steps[0].Descendant = true
+	}
+	return steps, nil
+}
+
+// xpathPreds returns the list of predicates like [pred1][pred2]
+func xpathPreds(input string) ([]string, bool) {
+	var (
+		ret   []string
+		quote byte
+	)
+	start := -1
+	for i := 0; i < len(input); i++ {
+		ch := input[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case start >= 0 && (ch == '"' || ch == '\''):
+			quote = ch
+		case ch == '[' && start < 0:
+			start = i + 1
+		case ch == ']' && start >= 0:
+			pred := strings.TrimSpace(input[start:i])
+			if len(pred) == 0 {
+				return nil, false
+			}
+			ret = append(ret, pred)
+			start = -1
+		case start < 0 && ch != ' ':
+			return nil, false
+		}
+	}
+	return ret, start < 0 && quote == 0
+}
+
+func isXPathName(name string) bool {
+	if name == `*` {
+		return true
+	}
+	for i, ch := range name {
+		if !(ch == '_' || ch == ':' || ch == '-' || ch == '.' || ch >= 'a' && ch <= 'z' ||
+			ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' && i > 0 || ch > 0x7f) {
+			return false
+		}
+	}
+	return len(name) > 0
+}
+
+// isXPathValue returns true if the step returns attributes or text
+func isXPathValue(test string) bool {
+	return strings.HasPrefix(test, `@`) || test == `text()`
+}
+
+// xpathString returns the value of the quoted literal or the number
+func xpathString(value string) (string, bool) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1], true
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value, true
+	}
+	return ``, false
+}
+
+// xpathMatch checks the predicate for the element at the position of the node list
+func xpathMatch(element *core.Map, pred string, pos, size int) (bool, error) {
+	if index, err := strconv.Atoi(pred); err == nil {
+		return index == pos, nil
+	}
+	if pred == `last()` {
+		return pos == size, nil
+	}
+	var (
+		value string
+		check bool
+	)
+	left := pred
+	if eq := splitXPath(pred, '='); len(eq) == 2 {
+		var ok bool
+		left = strings.TrimSpace(eq[0])
+		if value, ok = xpathString(strings.TrimSpace(eq[1])); !ok {
+			return false, fmt.Errorf(ErrorText(ErrXPath), pred)
+		}
+		check = true
+	} else if len(eq) > 2 {
+		return false, fmt.Errorf(ErrorText(ErrXPath), pred)
+	}
+	var values []string
+	switch {
+	case left == `text()` || left == `.`:
+		values = append(values, xmlField(element, `text`).Data.(string))
+	case strings.HasPrefix(left, `@`) && isXPathName(left[1:]):
+		values = xpathValues(element, left)
+	case isXPathName(left):
+		for _, child := range xmlChildren(element) {
+			if left == `*` || xmlField(child, `name`).Data.(string) == left {
+				values = append(values, xmlField(child, `text`).Data.(string))
+			}
+		}
+	default:
+		return false, fmt.Errorf(ErrorText(ErrXPath), pred)
+	}
+	for _, item := range values {
+		if !check || item == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// xpathValues returns the values of the attributes or the text of the element
+func xpathValues(element *core.Map, test string) []string {
+	var ret []string
+	if test == `text()` {
+		if text := xmlField(element, `text`).Data.(string); len(text) > 0 {
+			ret = append(ret, text)
+		}
+		return ret
+	}
+	attrs := xmlField(element, `attrs`).Data.(*core.Map)
+	for _, key := range attrs.Keys {
+		if test == `@*` || test[1:] == key {
+			ret = append(ret, attrs.Data[key].(*core.Obj).Data.(string))
+		}
+	}
+	return ret
+}
+
+// xpathDescendants appends the element and all its descendants in the document order
+func xpathDescendants(ret []*core.Map, element *core.Map) []*core.Map {
+	ret = append(ret, element)
+	for _, child := range xmlChildren(element) {
+		ret = xpathDescendants(ret, child)
+	}
+	return ret
+}
+
+// XPath returns the text of the elements or the values of the attributes which
+// match the XPath expression
+func XPath(input, expr string) (*core.Array, error) {
+	steps, err := parseXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	root, err := XmlToObj(input)
+	if err != nil {
+		return nil, err
+	}
+	doc := newXmlElement(``)
+	xmlField(doc, `children`).Data.(*core.Array).Data = []interface{}{root}
+	nodes := []*core.Map{doc}
+	ret := core.NewArray()
+	for _, step := range steps {
+		if step.Descendant {
+			var all []*core.Map
+			for _, node := range nodes {
+				all = xpathDescendants(all, node)
+			}
+			nodes = all
+		}
+		if isXPathValue(step.Test) {
+			for _, node := range nodes {
+				for _, value := range xpathValues(node, step.Test) {
+					ret.Data = append(ret.Data, value)
+				}
+			}
+			return ret, nil
+		}
+		var next []*core.Map
+		used := make(map[*core.Map]bool)
+		for _, node := range nodes {
+			var matched []*core.Map
+			for _, child := range xmlChildren(node) {
+				if step.Test == `*` || xmlField(child, `name`).Data.(string) == step.Test {
+					matched = append(matched, child)
+				}
+			}
+			for _, pred := range step.Preds {
+				var filtered []*core.Map
+				for i, child := range matched {
+					ok, err := xpathMatch(child, pred, i+1, len(matched))
+					if err != nil {
+						return nil, err
+					}
+					if ok {
+						filtered = append(filtered, child)
+					}
+				}
+				matched = filtered
+			}
+			for _, child := range matched {
+				if !used[child] {
+					used[child] = true
+					next = append(next, child)
+				}
+			}
+		}
+		nodes = next
+	}
+	for _, node := range nodes {
+		ret.Data = append(ret.Data, xmlField(node, `text`).Data.(string))
+	}
+	return ret, nil
+}
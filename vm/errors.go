@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+
+	"github.com/gentee/gentee/core"
 )
 
 const (
@@ -85,6 +87,44 @@ const (
 	ErrPlaySize
 	// ErrPlayAllSize is returned when the summary files size limit reached in Playground mode
 	ErrPlayAllSize
+	// ErrDeadlock is returned when all threads are waiting for each other
+	ErrDeadlock
+	// ErrUnlock is returned when the unlocked lock is unlocked
+	ErrUnlock
+	// ErrTimeout is returned when the deadline of timeout block has been exceeded
+	ErrTimeout
+	// ErrIfaceEmpty is returned in case of calling the method of the empty interface
+	ErrIfaceEmpty
+	// ErrEnumValue is returned when the string is not the name of the enum item
+	ErrEnumValue
+	// ErrCSVOption is returned when the option of CSV functions is invalid
+	ErrCSVOption
+	// ErrParseLine is returned when the data can't be parsed at the line
+	ErrParseLine
+	// ErrTomlRoot is returned when the object is not a map for TOML encoding
+	ErrTomlRoot
+	// ErrTomlNull is returned when the object has a null value for TOML encoding
+	ErrTomlNull
+	// ErrXmlObj is returned when the object is not the XML element
+	ErrXmlObj
+	// ErrXPath is returned when the XPath expression is invalid
+	ErrXPath
+	// ErrArchivePath is returned when the file of the archive is outside of the directory
+	ErrArchivePath
+	// ErrCompress is returned when the compression algorithm is not supported
+	ErrCompress
+	// ErrHashAlgo is returned when the hash algorithm is not supported
+	ErrHashAlgo
+	// ErrCryptoKey is returned when the key size is invalid for AES encryption
+	ErrCryptoKey
+	// ErrDecrypt is returned when the data can't be decrypted
+	ErrDecrypt
+	// ErrKeyAlgo is returned when the key derivation algorithm is not supported
+	ErrKeyAlgo
+	// ErrIfaceCall is returned when the struct value doesn't have the method of the interface
+	ErrIfaceCall
+	// ErrUnlockOwner is returned when the unlocked lock is held by another thread
+	ErrUnlockOwner
 
 	// ErrEmbedded means golang error in embedded functions
 	ErrEmbedded = 254
@@ -106,14 +146,38 @@ type RuntimeError struct {
 	ID      int
 	Message string
 	Trace   []TraceInfo
+	Cause   *RuntimeError // the wrapped error
+	Data    *core.Obj     // the attached data
 }
 
 func (re *RuntimeError) Error() string {
 	if len(re.Trace) == 0 {
-		return re.Message
+		return re.Text()
 	}
 	si := re.Trace[len(re.Trace)-1]
-	return ErrFormat(si.Path, si.Line, si.Pos, re.Message)
+	return ErrFormat(si.Path, si.Line, si.Pos, re.Text())
+}
+
+// Text returns the message of the error together with the messages of the wrapped errors
+func (re *RuntimeError) Text() string {
+	if re.Cause == nil {
+		return re.Message
+	}
+	return re.Message + `: ` + re.Cause.Text()
+}
+
+// Unwrap returns the wrapped error. It is used by errors.Is and errors.As
+func (re *RuntimeError) Unwrap() error {
+	if re.Cause == nil {
+		return nil
+	}
+	return re.Cause
+}
+
+// Is reports whether the error has the same identifier as target. It is used by errors.Is
+func (re *RuntimeError) Is(target error) bool {
+	rterr, ok := target.(*RuntimeError)
+	return ok && rterr.ID == re.ID
 }
 
 var (
@@ -154,6 +218,25 @@ var (
 		ErrPlayCount:    `[Playground] file limit reached`,
 		ErrPlaySize:     `[Playground] file size limit reached`,
 		ErrPlayAllSize:  `[Playground] summary files size limit reached`,
+		ErrDeadlock:     `all threads are blocked: %s`,
+		ErrUnlock:       `%s is not locked`,
+		ErrTimeout:      `timeout has expired`,
+		ErrIfaceEmpty:   `interface has not been assigned`,
+		ErrEnumValue:    `%s is not the item of %s`,
+		ErrCSVOption:    `invalid CSV option %s`,
+		ErrParseLine:    `%s: line %d: %s`,
+		ErrTomlRoot:     `TOML document must be a map`,
+		ErrTomlNull:     `TOML doesn't support null value of %s`,
+		ErrXmlObj:       `invalid XML element %s`,
+		ErrXPath:        `invalid XPath expression %s`,
+		ErrArchivePath:  `illegal file path %s in the archive`,
+		ErrCompress:     `unsupported compression algorithm %s`,
+		ErrHashAlgo:     `unsupported hash algorithm %s`,
+		ErrCryptoKey:    `invalid key size %d, must be 16, 24 or 32 bytes`,
+		ErrDecrypt:      `decryption failed, wrong key or corrupted data`,
+		ErrKeyAlgo:      `unsupported key derivation algorithm %s`,
+		ErrIfaceCall:    `the method of the interface has not been found`,
+		ErrUnlockOwner:  `%s is locked by another thread`,
 
 		ErrRuntime: `you have found a runtime bug. Let us know, please`,
 	}
@@ -261,6 +344,8 @@ func runtimeError(rt *Runtime, pos int64, err interface{}, labels ...interface{}
 	var (
 		errText string
 		idError int
+		cause   *RuntimeError
+		data    *core.Obj
 	)
 	switch v := err.(type) {
 	case int:
@@ -269,6 +354,7 @@ func runtimeError(rt *Runtime, pos int64, err interface{}, labels ...interface{}
 	case *RuntimeError:
 		errText = v.Message
 		idError = v.ID
+		cause, data = v.Cause, v.Data
 	case error:
 		errText = v.Error()
 		idError = ErrEmbedded
@@ -280,5 +366,7 @@ func runtimeError(rt *Runtime, pos int64, err interface{}, labels ...interface{}
 		ID:      idError,
 		Message: errText,
 		Trace:   GetTrace(rt, pos),
+		Cause:   cause,
+		Data:    data,
 	}
 }
@@ -21,6 +21,67 @@ func errorºIntStr(code int64, text string, pars ...interface{}) error {
 	}
 }
 
+// errorºError throws the error
+func errorºError(err *RuntimeError) error {
+	return err
+}
+
+// NewErrorºIntStr creates a new error without throwing it
+func NewErrorºIntStr(rt *Runtime, code int64, text string) (*RuntimeError, error) {
+	return &RuntimeError{
+		ID:      int(code),
+		Message: text,
+		Trace:   GetTrace(rt, rt.Pos),
+	}, nil
+}
+
+// NewErrorºIntStrObj creates a new error with the attached data
+func NewErrorºIntStrObj(rt *Runtime, code int64, text string,
+	data *core.Obj) (*RuntimeError, error) {
+	ret, _ := NewErrorºIntStr(rt, code, text)
+	ret.Data = data
+	return ret, nil
+}
+
+// WrapºErrorStr returns a new error which wraps the error with the additional message
+func WrapºErrorStr(rt *Runtime, err *RuntimeError, text string,
+	pars ...interface{}) (*RuntimeError, error) {
+	if len(pars) > 0 {
+		text = fmt.Sprintf(text, pars...)
+	}
+	ret, _ := NewErrorºIntStr(rt, int64(err.ID), text)
+	ret.Cause = err
+	return ret, nil
+}
+
+// UnwrapºError returns the wrapped error or the empty error if there is not it
+func UnwrapºError(err *RuntimeError) (*RuntimeError, error) {
+	if err.Cause == nil {
+		return &RuntimeError{}, nil
+	}
+	return err.Cause, nil
+}
+
+// IsºErrorInt returns true if the error or any of the wrapped errors has the identifier
+func IsºErrorInt(err *RuntimeError, code int64) int64 {
+	for ; err != nil; err = err.Cause {
+		if err.ID == int(code) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ErrData returns the data attached to the error or to the nearest wrapped error
+func ErrData(err *RuntimeError) *core.Obj {
+	for ; err != nil; err = err.Cause {
+		if err.Data != nil {
+			return err.Data
+		}
+	}
+	return core.NewObj()
+}
+
 // ErrID returns the id of the error
 func ErrID(err *RuntimeError) int64 {
 	return int64(err.ID)
@@ -28,7 +89,7 @@ func ErrID(err *RuntimeError) int64 {
 
 // ErrText returns the text of the error
 func ErrText(err *RuntimeError) string {
-	return err.Message
+	return err.Text()
 }
 
 func getTrace(rt *Runtime, list []TraceInfo, it *core.Array) *core.Array {
@@ -44,9 +105,13 @@ func getTrace(rt *Runtime, list []TraceInfo, it *core.Array) *core.Array {
 	return it
 }
 
-// ErrTrace returns the trace of the error
+// ErrTrace returns the trace of the error followed by the traces of the wrapped errors
 func ErrTrace(rt *Runtime, err *RuntimeError) *core.Array {
-	return getTrace(rt, err.Trace, core.NewArray())
+	ret := core.NewArray()
+	for ; err != nil; err = err.Cause {
+		getTrace(rt, err.Trace, ret)
+	}
+	return ret
 }
 
 // Trace gets trace information
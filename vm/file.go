@@ -5,9 +5,6 @@
 package vm
 
 import (
-	"crypto/md5"
-	"crypto/sha256"
-	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"os"
@@ -203,21 +200,7 @@ func GetCurDir() (string, error) {
 
 // Md5FileºStr returns md5 hash of the file as a hex string
 func Md5FileºStr(rt *Runtime, filename string) (string, error) {
-	if rt.Owner.Settings.IsPlayground {
-		if err := CheckPlaygroundLimits(rt.Owner, filename, NoLimit); err != nil {
-			return ``, err
-		}
-	}
-	file, err := os.Open(filename)
-	if err != nil {
-		return ``, err
-	}
-	defer file.Close()
-	hash := md5.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return ``, err
-	}
-	return hex.EncodeToString(hash.Sum(nil)[:]), nil
+	return HashFileºStrStr(rt, `md5`, filename)
 }
 
 // ReadDirºStr reads a directory
@@ -393,16 +376,7 @@ func SetFileTimeºStrTime(name string, ftime *Struct) error {
 
 // Sha256FileºStr returns sha256 hash of the file as a hex string
 func Sha256FileºStr(filename string) (string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return ``, err
-	}
-	defer file.Close()
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return ``, err
-	}
-	return hex.EncodeToString(hash.Sum(nil)[:]), nil
+	return hashFile(`sha256`, filename)
 }
 
 // TempDir returns the temporary directory
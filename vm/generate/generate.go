@@ -20,11 +20,22 @@ import (
 	"github.com/gentee/gentee/core"
 )
 
-func str2type(in string) (ret string) {
+func str2type(in string, generics ...string) (ret string) {
+	for _, item := range generics {
+		if in == item {
+			return `core.TYPEPARAM`
+		}
+	}
+	if strings.HasPrefix(in, `fn.`) {
+		return `core.TYPEFUNC`
+	}
+	if strings.HasPrefix(in, `thread.`) {
+		return `core.TYPEINT`
+	}
 	switch in {
 	case ``:
 		ret = `core.TYPENONE`
-	case `int`, `thread`:
+	case `int`, `thread`, `enum`:
 		ret = `core.TYPEINT`
 	case `bool`:
 		ret = `core.TYPEBOOL`
@@ -46,10 +57,12 @@ func str2type(in string) (ret string) {
 		ret = `core.TYPESET`
 	case `obj`:
 		ret = `core.TYPEOBJ`
+	case `iface`:
+		ret = `core.TYPEIFACE`
 	default:
 		if in == `arr` || strings.HasPrefix(in, `arr.`) {
 			ret = `core.TYPEARR`
-		} else if in == `map` || strings.HasPrefix(in, `map.`) {
+		} else if in == `map` || strings.HasPrefix(in, `map.`) || strings.HasPrefix(in, `map[`) {
 			ret = `core.TYPEMAP`
 		} else {
 			ret = `core.TYPESTRUCT`
@@ -58,13 +71,13 @@ func str2type(in string) (ret string) {
 	return
 }
 
-func str2pars(in string) string {
+func str2pars(in string, generics []string) string {
 	if len(in) == 0 {
 		return `nil`
 	}
 	var types []string
 	for _, par := range strings.Split(in, `,`) {
-		types = append(types, str2type(strings.TrimSpace(par)))
+		types = append(types, str2type(strings.TrimSpace(par), generics...))
 	}
 	return `[]uint16{` + strings.Join(types, `,`) + `}`
 }
@@ -91,7 +104,7 @@ var EmbedFuncs = []core.Embed{
 	if input, err = ioutil.ReadFile(`generate/stdlib.txt`); err != nil {
 		log.Fatal(err)
 	}
-	re, err := regexp.Compile(`^([\wº]+)\(([\w ,\.\*]*)\)\s*([\w\.\*]*);([º\w]+);?(\w*)?`)
+	re, err := regexp.Compile(`^([\wº]+(?:<[\w,]+>)?)\(([\w ,\.\*\[\]]*)\)\s*([\w\.\*\[\]]*);([º\w]+);?(\w*)?`)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -109,6 +122,10 @@ var EmbedFuncs = []core.Embed{
 		crc += v
 		list := re.FindAllStringSubmatch(v, -1)
 		vals := list[0]
+		var generics []string
+		if lt := strings.IndexRune(vals[1], '<'); lt > 0 {
+			generics = strings.Split(vals[1][lt+1:len(vals[1])-1], `,`)
+		}
 		if strings.ToUpper(vals[4]) != vals[4] {
 			code = fmt.Sprint(i)
 			fnc = vals[4]
@@ -138,7 +155,8 @@ var EmbedFuncs = []core.Embed{
 		Func: %s, Return: %s, 
 		Params: %s, 
 		Variadic: %v, Runtime: %v, CanError: %v},
-`, vals[1], vals[2], vals[3], code, fnc, str2type(vals[3]), str2pars(vals[2]),
+`, vals[1], vals[2], vals[3], code, fnc, str2type(vals[3], generics...),
+			str2pars(vals[2], generics),
 			embed.Variadic, embed.Runtime, embed.CanError)
 	}
 	out += fmt.Sprintf(`}
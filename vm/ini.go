@@ -0,0 +1,216 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// iniLine is a parsed line of INI file
+type iniLine struct {
+	Text    string // the source line
+	Section string // the name of the section header
+	Key     string // the key of key=value line
+	Prefix  string // the part of the line before the value
+	Value   string
+	Header  bool
+}
+
+func isIniComment(line string) bool {
+	return len(line) == 0 || line[0] == ';' || line[0] == '#'
+}
+
+// unquoteIni removes the surrounding quotes of the value
+func unquoteIni(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// quoteIni quotes the value if it can't be read back as is
+func quoteIni(value string) string {
+	if value != strings.TrimSpace(value) || unquoteIni(value) != value ||
+		strings.ContainsAny(value, `;#`) {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// parseIni splits INI data into lines
+func parseIni(data string) ([]iniLine, error) {
+	input := strings.Split(strings.ReplaceAll(strings.TrimPrefix(data, bom), "\r\n", "\n"), "\n")
+	if len(input) > 0 && len(input[len(input)-1]) == 0 {
+		input = input[:len(input)-1]
+	}
+	ret := make([]iniLine, len(input))
+	for i, text := range input {
+		line := strings.TrimSpace(text)
+		ret[i].Text = text
+		switch {
+		case isIniComment(line):
+		case line[0] == '[':
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf(ErrorText(ErrParseLine), `ini`, i+1, `invalid section`)
+			}
+			ret[i].Header = true
+			ret[i].Section = strings.TrimSpace(line[1 : len(line)-1])
+		default:
+			eq := strings.IndexRune(text, '=')
+			if eq < 0 || len(strings.TrimSpace(text[:eq])) == 0 {
+				return nil, fmt.Errorf(ErrorText(ErrParseLine), `ini`, i+1, `expected key=value`)
+			}
+			ret[i].Key = strings.TrimSpace(text[:eq])
+			value := strings.TrimLeft(text[eq+1:], " \t")
+			ret[i].Prefix = text[:len(text)-len(value)]
+			ret[i].Value = unquoteIni(strings.TrimSpace(value))
+		}
+	}
+	return ret, nil
+}
+
+// ReadIni reads INI file to map.map.str. The keys before the first section are in
+// the section with the empty name
+func ReadIni(rt *Runtime, filename string) (*core.Map, error) {
+	data, err := ReadFileºStr(rt, filename)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := parseIni(data)
+	if err != nil {
+		return nil, err
+	}
+	ret := core.NewMap()
+	var section *core.Map
+	getSection := func(name string) *core.Map {
+		if item, ok := ret.Data[name]; ok {
+			return item.(*core.Map)
+		}
+		sect := core.NewMap()
+		ret.Keys = append(ret.Keys, name)
+		ret.Data[name] = sect
+		return sect
+	}
+	for _, line := range lines {
+		switch {
+		case line.Header:
+			section = getSection(line.Section)
+		case len(line.Key) > 0:
+			if section == nil {
+				section = getSection(``)
+			}
+			if _, ok := section.Data[line.Key]; !ok {
+				section.Keys = append(section.Keys, line.Key)
+			}
+			section.Data[line.Key] = line.Value
+		}
+	}
+	return ret, nil
+}
+
+// WriteIni writes map.map.str to INI file. If the file exists then its comments and
+// the order of the sections and the keys are kept
+func WriteIni(rt *Runtime, filename string, data *core.Map) error {
+	var (
+		lines []iniLine
+		out   []string
+	)
+	if _, err := os.Stat(filename); err == nil {
+		input, err := ReadFileºStr(rt, filename)
+		if err != nil {
+			return err
+		}
+		if lines, err = parseIni(input); err != nil {
+			return err
+		}
+	}
+	written := make(map[string]bool)
+	var (
+		section *core.Map
+		name    string
+	)
+	skip := false
+	insert := 0 // the index of out where new keys of the current section are inserted
+	flush := func() {
+		if section == nil {
+			return
+		}
+		var add []string
+		for _, key := range section.Keys {
+			if !written[name+`]`+key] {
+				add = append(add, key+` = `+quoteIni(section.Data[key].(string)))
+			}
+		}
+		out = append(out[:insert], append(add, out[insert:]...)...)
+	}
+	startSection := func(sect string) {
+		flush()
+		name = sect
+		section = nil
+		if item, ok := data.Data[sect]; ok && !written[sect] {
+			section = item.(*core.Map)
+		}
+		written[sect] = true
+		skip = section == nil
+		insert = len(out)
+	}
+	startSection(``)
+	// the comments before the first section are always kept
+	skip = false
+	for _, line := range lines {
+		switch {
+		case line.Header:
+			startSection(line.Section)
+			if skip {
+				continue
+			}
+			insert = len(out) + 1
+		case len(line.Key) > 0:
+			if section == nil || written[name+`]`+line.Key] {
+				continue
+			}
+			value, ok := section.Data[line.Key]
+			if !ok {
+				continue
+			}
+			written[name+`]`+line.Key] = true
+			if value.(string) != line.Value {
+				line.Text = line.Prefix + quoteIni(value.(string))
+			}
+			insert = len(out) + 1
+		case skip:
+			continue
+		}
+		out = append(out, line.Text)
+	}
+	flush()
+	section = nil
+	for _, sect := range data.Keys {
+		if written[sect] {
+			continue
+		}
+		if len(out) > 0 && len(strings.TrimSpace(out[len(out)-1])) > 0 {
+			out = append(out, ``)
+		}
+		out = append(out, `[`+sect+`]`)
+		startSection(sect)
+	}
+	flush()
+	var result string
+	if len(out) > 0 {
+		result = strings.Join(out, "\n") + "\n"
+	}
+	if rt.Owner.Settings.IsPlayground {
+		if err := CheckPlaygroundLimits(rt.Owner, filename, int64(len(result))); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(filename, []byte(result), os.ModePerm)
+}
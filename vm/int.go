@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 )
 
 // AbsºInt the absolute value of x.
@@ -149,3 +150,29 @@ func Random(limit int64) int64 {
 func strºInt(val int64) string {
 	return strconv.FormatInt(val, 10)
 }
+
+// enumItems splits the description of the enum like Name:Item1,Item2 into the name and items
+func enumItems(enum string) (string, []string) {
+	colon := strings.IndexByte(enum, ':')
+	return enum[:colon], strings.Split(enum[colon+1:], `,`)
+}
+
+// sysEnumInt returns the enum value by the name of the item
+func sysEnumInt(name, enum string) (int64, error) {
+	typeName, items := enumItems(enum)
+	for i, item := range items {
+		if item == name {
+			return int64(i), nil
+		}
+	}
+	return 0, fmt.Errorf(ErrorText(ErrEnumValue), name, typeName)
+}
+
+// sysEnumStr returns the name of the item of the enum
+func sysEnumStr(val int64, enum string) string {
+	_, items := enumItems(enum)
+	if val < 0 || val >= int64(len(items)) {
+		return strºInt(val)
+	}
+	return items[val]
+}
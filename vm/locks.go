@@ -0,0 +1,234 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// namedLock is a read-write lock with the name
+type namedLock struct {
+	Writer  int64           // the id of the thread + 1 which has locked for writing
+	Readers map[int64]int64 // counts of read locks by the ids of threads
+	Notify  chan struct{}
+}
+
+func getLock(rt *Runtime, name string) *namedLock {
+	lock := rt.Owner.Locks[name]
+	if lock == nil {
+		lock = &namedLock{Readers: make(map[int64]int64), Notify: make(chan struct{})}
+		rt.Owner.Locks[name] = lock
+	}
+	return lock
+}
+
+func (lock *namedLock) release() {
+	close(lock.Notify)
+	lock.Notify = make(chan struct{})
+}
+
+// isDeadlock returns the list of the blocked threads if all active threads are waiting
+func isDeadlock(rt *Runtime) string {
+	rt.Owner.ThreadMutex.RLock()
+	defer rt.Owner.ThreadMutex.RUnlock()
+	list := make([]string, 0, len(rt.Owner.Blocked))
+	for i, thread := range rt.Owner.Runtimes {
+		if thread.Thread.Status >= ThFinished {
+			continue
+		}
+		name, ok := rt.Owner.Blocked[int64(i)]
+		if !ok {
+			if thread.Thread.Status != ThWait {
+				return ``
+			}
+			continue
+		}
+		list = append(list, fmt.Sprintf(`thread %d waits for %s`, i, name))
+	}
+	sort.Strings(list)
+	return strings.Join(list, `, `)
+}
+
+// waitLock locks the named lock. If timeout is less than zero then it waits until the lock is free.
+func waitLock(rt *Runtime, name string, read bool, timeout int64) (bool, error) {
+	var deadline <-chan time.Time
+	if timeout >= 0 {
+		deadline = time.After(time.Duration(timeout) * time.Millisecond)
+	}
+	vm := rt.Owner
+	for {
+		vm.NamedMutex.Lock()
+		lock := getLock(rt, name)
+		if lock.Writer == 0 && (read || len(lock.Readers) == 0) {
+			if read {
+				lock.Readers[rt.ThreadID]++
+			} else {
+				lock.Writer = rt.ThreadID + 1
+			}
+			delete(vm.Blocked, rt.ThreadID)
+			vm.NamedMutex.Unlock()
+			return true, nil
+		}
+		if lock.Writer == rt.ThreadID+1 && timeout < 0 {
+			vm.NamedMutex.Unlock()
+			return false, fmt.Errorf(ErrorText(ErrDeadlock), fmt.Sprintf(`thread %d waits for %s`,
+				rt.ThreadID, name))
+		}
+		if timeout < 0 {
+			vm.Blocked[rt.ThreadID] = name
+			if list := isDeadlock(rt); len(list) > 0 {
+				delete(vm.Blocked, rt.ThreadID)
+				vm.NamedMutex.Unlock()
+				return false, fmt.Errorf(ErrorText(ErrDeadlock), list)
+			}
+		}
+		notify := lock.Notify
+		vm.NamedMutex.Unlock()
+
+		err := rt.timeoutError()
+		if err == nil && rt.ThreadID == 0 {
+			select {
+			case <-notify:
+			case <-deadline:
+				return false, nil
+			case <-time.After(time.Duration(SleepStep) * time.Millisecond):
+			case <-rt.timeoutTick():
+			case err = <-vm.ChError:
+			}
+		} else if err == nil {
+			select {
+			case <-notify:
+			case <-deadline:
+				return false, nil
+			case <-time.After(time.Duration(SleepStep) * time.Millisecond):
+			case <-rt.timeoutTick():
+			case x := <-rt.Thread.Chan:
+				if x == ThCmdClose {
+					rt.setStatus(ThClosed)
+					err = fmt.Errorf(ErrorText(ErrThreadClosed))
+				}
+			}
+		}
+		if err != nil {
+			vm.NamedMutex.Lock()
+			delete(vm.Blocked, rt.ThreadID)
+			vm.NamedMutex.Unlock()
+			return false, err
+		}
+	}
+}
+
+// LockºStr locks the named lock for writing
+func LockºStr(rt *Runtime, name string) error {
+	_, err := waitLock(rt, name, false, -1)
+	return err
+}
+
+// RLockºStr locks the named lock for reading
+func RLockºStr(rt *Runtime, name string) error {
+	_, err := waitLock(rt, name, true, -1)
+	return err
+}
+
+// TryLockºStrInt tries to lock the named lock for writing during the timeout in milliseconds
+func TryLockºStrInt(rt *Runtime, name string, timeout int64) (int64, error) {
+	if timeout < 0 {
+		return 0, fmt.Errorf(ErrorText(ErrInvalidParam))
+	}
+	ok, err := waitLock(rt, name, false, timeout)
+	if ok {
+		return 1, err
+	}
+	return 0, err
+}
+
+// TryRLockºStrInt tries to lock the named lock for reading during the timeout in milliseconds
+func TryRLockºStrInt(rt *Runtime, name string, timeout int64) (int64, error) {
+	if timeout < 0 {
+		return 0, fmt.Errorf(ErrorText(ErrInvalidParam))
+	}
+	ok, err := waitLock(rt, name, true, timeout)
+	if ok {
+		return 1, err
+	}
+	return 0, err
+}
+
+// UnlockºStr unlocks the named lock for writing. The lock must be locked by the current thread
+func UnlockºStr(rt *Runtime, name string) error {
+	rt.Owner.NamedMutex.Lock()
+	defer rt.Owner.NamedMutex.Unlock()
+	lock := rt.Owner.Locks[name]
+	if lock == nil || lock.Writer == 0 {
+		return fmt.Errorf(ErrorText(ErrUnlock), name)
+	}
+	if lock.Writer != rt.ThreadID+1 {
+		return fmt.Errorf(ErrorText(ErrUnlockOwner), name)
+	}
+	lock.Writer = 0
+	lock.release()
+	return nil
+}
+
+// RUnlockºStr unlocks the named lock for reading. The lock must be locked by the current thread
+func RUnlockºStr(rt *Runtime, name string) error {
+	rt.Owner.NamedMutex.Lock()
+	defer rt.Owner.NamedMutex.Unlock()
+	lock := rt.Owner.Locks[name]
+	if lock == nil || len(lock.Readers) == 0 {
+		return fmt.Errorf(ErrorText(ErrUnlock), name)
+	}
+	if lock.Readers[rt.ThreadID] == 0 {
+		return fmt.Errorf(ErrorText(ErrUnlockOwner), name)
+	}
+	if lock.Readers[rt.ThreadID]--; lock.Readers[rt.ThreadID] == 0 {
+		delete(lock.Readers, rt.ThreadID)
+	}
+	if len(lock.Readers) == 0 {
+		lock.release()
+	}
+	return nil
+}
+
+// releaseLocks unlocks the named locks which are held by the finished thread
+func releaseLocks(rt *Runtime) {
+	rt.Owner.NamedMutex.Lock()
+	defer rt.Owner.NamedMutex.Unlock()
+	for _, lock := range rt.Owner.Locks {
+		held := lock.Writer == rt.ThreadID+1
+		if held {
+			lock.Writer = 0
+		}
+		if lock.Readers[rt.ThreadID] > 0 {
+			delete(lock.Readers, rt.ThreadID)
+			held = true
+		}
+		if held {
+			lock.release()
+		}
+	}
+}
+
+// AtomicAddºStrInt adds the value to the named counter and returns the new value
+func AtomicAddºStrInt(rt *Runtime, name string, value int64) int64 {
+	rt.Owner.NamedMutex.Lock()
+	defer rt.Owner.NamedMutex.Unlock()
+	rt.Owner.Atomics[name] += value
+	return rt.Owner.Atomics[name]
+}
+
+// AtomicCASºStrIntInt sets a new value of the named counter if it equals the old value
+func AtomicCASºStrIntInt(rt *Runtime, name string, old, value int64) int64 {
+	rt.Owner.NamedMutex.Lock()
+	defer rt.Owner.NamedMutex.Unlock()
+	if rt.Owner.Atomics[name] != old {
+		return 0
+	}
+	rt.Owner.Atomics[name] = value
+	return 1
+}
@@ -6,6 +6,8 @@ package vm
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/gentee/gentee/core"
 )
@@ -46,3 +48,77 @@ func KeyºMapInt(pmap *core.Map, index int64) (string, error) {
 	}
 	return pmap.Keys[index], nil
 }
+
+// DelºIntMapInt deletes the int key and its value from the map
+func DelºIntMapInt(pmap *core.Map, key int64) *core.Map {
+	return DelºMapStr(pmap, strconv.FormatInt(key, 10))
+}
+
+// IsKeyºIntMapInt returns true if there is the int key in the map
+func IsKeyºIntMapInt(pmap *core.Map, key int64) int64 {
+	return IsKeyºMapStr(pmap, strconv.FormatInt(key, 10))
+}
+
+// KeyºIntMapInt returns the int key by the index
+func KeyºIntMapInt(pmap *core.Map, index int64) (int64, error) {
+	key, err := KeyºMapInt(pmap, index)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(key, 10, 64)
+}
+
+// KeysºMap returns the keys of the map in the current order
+func KeysºMap(pmap *core.Map) *core.Array {
+	ret := core.NewArray()
+	for _, key := range pmap.Keys {
+		ret.Data = append(ret.Data, key)
+	}
+	return ret
+}
+
+// KeysºIntMap returns the int keys of the map in the current order
+func KeysºIntMap(pmap *core.Map) *core.Array {
+	ret := core.NewArray()
+	for _, key := range pmap.Keys {
+		ikey, _ := strconv.ParseInt(key, 10, 64)
+		ret.Data = append(ret.Data, ikey)
+	}
+	return ret
+}
+
+// MergeºMapMap copies the keys and values of the second map to the first map
+func MergeºMapMap(rt *Runtime, pmap *core.Map, src *core.Map) *core.Map {
+	for _, key := range src.Keys {
+		var ptr interface{}
+		CopyVar(rt, &ptr, src.Data[key])
+		pmap.SetIndex(key, ptr)
+	}
+	return pmap
+}
+
+// SortKeysºMap sorts the keys of the map
+func SortKeysºMap(pmap *core.Map) *core.Map {
+	sort.Strings(pmap.Keys)
+	return pmap
+}
+
+// SortKeysºIntMap sorts the int keys of the map in numerical order
+func SortKeysºIntMap(pmap *core.Map) *core.Map {
+	sort.Slice(pmap.Keys, func(i, j int) bool {
+		left, _ := strconv.ParseInt(pmap.Keys[i], 10, 64)
+		right, _ := strconv.ParseInt(pmap.Keys[j], 10, 64)
+		return left < right
+	})
+	return pmap
+}
+
+// ValuesºMap returns the values of the map in the order of its keys
+func ValuesºMap(rt *Runtime, pmap *core.Map) *core.Array {
+	ret := core.NewArray()
+	ret.Data = make([]interface{}, len(pmap.Keys))
+	for i, key := range pmap.Keys {
+		CopyVar(rt, &ret.Data[i], pmap.Data[key])
+	}
+	return ret
+}
@@ -110,12 +110,22 @@ func intºObjDef(val *core.Obj, def int64) (int64, error) {
 
 // IsNil returns true if the object is undefined
 func IsNil(val *core.Obj) int64 {
-	if val.Data == nil {
+	if val == nil || val.Data == nil {
 		return 1
 	}
 	return 0
 }
 
+// IsMap returns true if the object is a map
+func IsMap(val *core.Obj) int64 {
+	if val != nil {
+		if _, ok := val.Data.(*core.Map); ok {
+			return 1
+		}
+	}
+	return 0
+}
+
 // itemºObjInt returns an item from array object
 func itemºObjInt(val *core.Obj, ind int64) (ret *core.Obj, err error) {
 	if val == nil || val.Data == nil {
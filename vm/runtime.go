@@ -41,17 +41,52 @@ func (rt *Runtime) Run(i int64) (result interface{}, err error) {
 	code := rt.Owner.Exec.Code
 	end := int64(len(code))
 
-	errHandle := func(pos int64, errPar interface{}, pars ...interface{}) {
+	// deferred calls the latest deferred block if it belongs to the block with the index
+	// from or higher in Calls. The deferred block returns to offset or passes unwind error
+	deferred := func(from int, offset int64, unwind error) bool {
+		last := len(rt.Defers) - 1
+		if last < 0 || rt.Defers[last].Depth < from {
+			return false
+		}
+		if depth := rt.Defers[last].Depth + 1; unwind != nil && depth < len(rt.Calls) {
+			// the blocks above the owner of the deferred block are not required anymore
+			curTop := top
+			top = rt.Calls[depth]
+			rt.Calls = rt.Calls[:depth]
+			for j := top.Any; j < curTop.Any; j++ {
+				rt.SAny[j] = nil
+			}
+		}
+		rt.Calls = append(rt.Calls, Call{
+			IsLocal: true,
+			Offset:  int32(offset),
+			Int:     top.Int,
+			Float:   top.Float,
+			Str:     top.Str,
+			Any:     top.Any,
+			Unwind:  unwind,
+		})
+		i = int64(rt.Defers[last].Offset)
+		rt.Defers = rt.Defers[:last]
+		rt.ParCount = 0
+		return true
+	}
+
+	// unwind passes err to the nearest try block. The deferred blocks are called before
+	unwind := func() {
 		k := len(rt.Calls) - 1
 		for ; k > 0; k-- {
 			if rt.Calls[k].Flags&core.BlTry != 0 {
 				break
 			}
 		}
-		//fmt.Println(`errHandle`, pos, rt.Owner.Exec.Pos)
-		err = runtimeError(rt, pos, errPar, pars...)
 		if k <= 0 {
-			i = end + 1
+			if !deferred(0, i, err) {
+				i = end + 1
+			}
+			return
+		}
+		if deferred(k+1, i, err) {
 			return
 		}
 		top = rt.Calls[k]
@@ -63,6 +98,12 @@ func (rt *Runtime) Run(i int64) (result interface{}, err error) {
 		rt.ParCount = 1
 	}
 
+	errHandle := func(pos int64, errPar interface{}, pars ...interface{}) {
+		//fmt.Println(`errHandle`, pos, rt.Owner.Exec.Pos)
+		err = runtimeError(rt, pos, errPar, pars...)
+		unwind()
+	}
+
 main:
 	for i < end {
 		switch code[i] & 0x0fff {
@@ -83,8 +124,18 @@ main:
 			rt.SStr[top.Str] = rt.Owner.Exec.Strings[(code[i])>>16]
 			top.Str++
 		case core.PUSHFUNC:
+			count = int(code[i] >> 16)
 			i++
-			rt.SAny[top.Any] = &Fn{Func: int32(code[i])}
+			fn := &Fn{Func: int32(code[i])}
+			if count > 0 {
+				fn.Env = make([]OptValue, count)
+				top.Any -= int32(count)
+				for j := 0; j < count; j++ {
+					i++
+					fn.Env[j] = OptValue{Var: int32(code[i]), Value: rt.SAny[top.Any+int32(j)]}
+				}
+			}
+			rt.SAny[top.Any] = fn
 			top.Any++
 		case core.ADD:
 			top.Int--
@@ -498,7 +549,7 @@ main:
 				case core.STACKSTR:
 					rt.SStr[root] = rt.SStr[top.Str-1]
 				default:
-					if rt.SAny[root] == rt.SAny[top.Any-1] {
+					if assign == core.ASSIGN && rt.SAny[root] == rt.SAny[top.Any-1] {
 						errHandle(i, ErrAssignment)
 						continue main
 						//return nil, runtimeError(rt, i, ErrAssignment)
@@ -666,9 +717,14 @@ main:
 				top.Int--
 			}
 		case core.CYCLE:
+			rt.setPos(i)
 			lenCalls := len(rt.Calls) - 1
 			rt.Calls[lenCalls].Cycle--
 			//			fmt.Println(`CYCLE`, rt.Calls[lenCalls].Cycle, rt.SInt[:top.Int], rt.SStr[:top.Str], rt.SAny[:top.Any])
+			if len(rt.Timeouts) > 0 && rt.isExpired() {
+				errHandle(i, ErrTimeout)
+				continue main
+			}
 			if rt.Calls[lenCalls].Cycle == 0 {
 				if rt.Owner.Settings.IsPlayground {
 					errHandle(i, ErrPlayCycle, rt.Owner.Settings.Cycle)
@@ -846,6 +902,9 @@ main:
 			//			fmt.Println(`INIT OK`, rt.SInt[:top.Int], rt.SAny[:top.Any])
 			//			fmt.Println(`INITVARS`, rt.Calls)
 		case core.DELVARS:
+			if deferred(len(rt.Calls)-1, i-1, nil) {
+				continue
+			}
 			curTop := top
 			top = rt.Calls[len(rt.Calls)-1]
 			rt.Calls = rt.Calls[:len(rt.Calls)-1]
@@ -1079,6 +1138,9 @@ main:
 					break
 				}
 			}
+			if deferred(k, i-1, nil) {
+				continue
+			}
 			for j := rt.Calls[k].Any; j < top.Any; j++ {
 				rt.SAny[j] = nil
 			}
@@ -1093,6 +1155,9 @@ main:
 					break
 				}
 			}
+			if deferred(k, i-1, nil) {
+				continue
+			}
 			for j := rt.Calls[k].Any; j < top.Any; j++ {
 				rt.SAny[j] = nil
 			}
@@ -1110,6 +1175,9 @@ main:
 					break
 				}
 			}
+			if isRecover && deferred(k, i-1, nil) || !isRecover && deferred(k+1, i-1, nil) {
+				continue
+			}
 			for j := rt.Calls[k].Any; j < top.Any; j++ {
 				rt.SAny[j] = nil
 			}
@@ -1130,6 +1198,9 @@ main:
 					break
 				}
 			}
+			if deferred(k, i-1, nil) {
+				continue
+			}
 			rt.Calls = rt.Calls[:k+1]
 			if len(rt.Calls) == 0 { // return from run function
 				switch retType {
@@ -1181,12 +1252,20 @@ main:
 					break
 				}
 			}
+			if deferred(k, i-1, nil) {
+				continue
+			}
 			rt.Calls = rt.Calls[:k+1]
 			if len(rt.Calls) == 0 {
 				break main
 			}
 			top = rt.Calls[k]
 			rt.Calls = rt.Calls[:k]
+			if top.Unwind != nil { // the deferred block has been called while unwinding
+				err = top.Unwind
+				unwind()
+				continue
+			}
 			i = int64(top.Offset)
 		case core.CONSTBYID:
 			i++
@@ -1202,13 +1281,33 @@ main:
 				rt.SStr[top.Str] = v.Value.(string)
 				top.Str++
 			}
+		case core.CALLIFACE:
+			i++
+			top.Str--
+			pstruct, _ := rt.SAny[top.Any-int32(code[i])].(*Struct)
+			if pstruct == nil || pstruct.Type.Methods == nil {
+				errHandle(i, ErrIfaceEmpty)
+				continue
+			}
+			method, ok := pstruct.Type.Methods[rt.SStr[top.Str]]
+			if !ok {
+				errHandle(i, ErrIfaceCall, pstruct.Type.Name+`.`+rt.SStr[top.Str])
+				continue
+			}
+			rt.SAny[top.Any] = &Fn{Func: method}
+			top.Any++
 		case core.CALLBYID:
 			rt.ParCount = int32(code[i]) >> 16
 			i++
+			rt.setPos(i)
 			id := int32(code[i])
 			if id == 0 {
 				top.Any--
-				id = rt.SAny[top.Any].(*Fn).Func
+				fn := rt.SAny[top.Any].(*Fn)
+				id = fn.Func
+				if len(fn.Env) > 0 {
+					rt.Optional = &fn.Env
+				}
 				if id == 0 {
 					errHandle(i, ErrFnEmpty)
 					continue main
@@ -1234,6 +1333,7 @@ main:
 			continue
 		case core.GOBYID:
 			var pars []int32
+			kept := code[i]&core.GoKept != 0
 			rt.ParCount = int32(code[i]) >> 16
 			i++
 			id := int32(code[i])
@@ -1245,12 +1345,13 @@ main:
 				}
 				rt.ParCount = 0
 			}
-			threadID := rt.GoThread(int64(rt.Owner.Exec.Funcs[id]), pars, &top)
+			threadID := rt.GoThread(int64(rt.Owner.Exec.Funcs[id]), pars, &top, kept)
 			rt.SInt[top.Int] = threadID
 			top.Int++
 		case core.EMBED:
 			var (
 				vCount int
+				tuple  *core.StructInfo
 			)
 			idEmbed := uint16(code[i] >> 16)
 			embed := EmbedFuncs[idEmbed]
@@ -1259,6 +1360,7 @@ main:
 				i++
 				vCount = int(code[i])
 			}
+			rt.setPos(i)
 			pars := make([]reflect.Value, count+vCount)
 			if vCount > 0 {
 				for j := vCount - 1; j >= 0; j-- {
@@ -1279,8 +1381,13 @@ main:
 				}
 				i += int64(vCount)
 			}
+			if embed.Tuple {
+				i++
+				tuple = &rt.Owner.Exec.Structs[(code[i]-core.TYPESTRUCT)>>8]
+			}
+			params, retType := typeParams(&embed, code, &i)
 			for i := count - 1; i >= 0; i-- {
-				switch embed.Params[i] & 0xf {
+				switch params[i] & 0xf {
 				case core.STACKFLOAT:
 					top.Float--
 					pars[i] = reflect.ValueOf(rt.SFloat[top.Float])
@@ -1307,7 +1414,17 @@ main:
 						continue
 					}
 				}
-				switch embed.Return & 0xf {
+				if tuple != nil {
+					// the tuple result is the struct value like the tuples of script functions
+					pstruct := NewStruct(rt, tuple)
+					for j := range pstruct.Values {
+						pstruct.Values[j] = result[j].Interface()
+					}
+					rt.SAny[top.Any] = pstruct
+					top.Any++
+					break
+				}
+				switch retType & 0xf {
 				case core.STACKNONE:
 				case core.STACKFLOAT:
 					rt.SFloat[top.Float] = result[0].Interface().(float64)
@@ -1342,6 +1459,33 @@ main:
 			}
 			i += int64(shift)
 			continue
+		case core.TIMEOUT:
+			top.Int--
+			rt.actualTimeouts()
+			rt.Timeouts = append(rt.Timeouts, Timeout{
+				Depth:    len(rt.Calls) + 1,
+				Offset:   int32(i + 1),
+				Deadline: rt.Owner.clock() + rt.SInt[top.Int]*int64(time.Millisecond),
+			})
+			i++
+			continue // the deadline is checked after the initialization of the block
+		case core.TIMEOUTEND:
+			rt.actualTimeouts()
+		case core.DEFER:
+			depth := len(rt.Calls) - 1 // the deferred block belongs to the body of the function
+			if code[i]>>16&core.DeferFinally != 0 {
+				depth++ // finally belongs to the block of try and catch
+			}
+			// the deferred blocks are sorted by depth
+			k := len(rt.Defers)
+			for k > 0 && rt.Defers[k-1].Depth > depth {
+				k--
+			}
+			rt.Defers = append(rt.Defers, Defer{})
+			copy(rt.Defers[k+1:], rt.Defers[k:])
+			rt.Defers[k] = Defer{Depth: depth, Offset: int32(i + 2)}
+			i += int64(code[i+1])
+			continue
 		case core.IOTA:
 			rt.Owner.Consts[rt.Owner.Exec.Init[0]] = Const{
 				Type:  core.TYPEINT,
@@ -1353,7 +1497,7 @@ main:
 				continue
 			}*/
 		step := SleepStep
-		check := len(rt.Owner.Runtimes) > 1
+		check := len(rt.Owner.Runtimes) > 1 || len(rt.Timeouts) > 0
 		for check || rt.Thread.Status == ThPaused || rt.Thread.Status == ThWait ||
 			rt.Thread.Sleep > 0 || rt.Owner.Stopped {
 			if rt.Owner.Stopped {
@@ -1367,6 +1511,18 @@ main:
 				time.Sleep(750) // May be it is better to use one more chan
 				continue
 			}
+			if len(rt.Timeouts) > 0 {
+				remain := rt.remainTimeout()
+				if remain <= 0 || ((rt.Thread.Sleep > 0 || rt.Thread.Status == ThWait) &&
+					rt.isExpired()) {
+					rt.breakWait()
+					errHandle(i, ErrTimeout)
+					continue main
+				}
+				if remain < step {
+					step = remain
+				}
+			}
 			var x int
 			if rt.ThreadID == 0 {
 				select {
@@ -1403,6 +1559,10 @@ main:
 				time.Sleep(time.Duration(step) * time.Millisecond)
 				rt.Thread.Sleep -= step
 			} else if rt.Thread.Status == ThPaused || rt.Thread.Status == ThWait {
+				var tick <-chan time.Time
+				if rt.Thread.Status == ThWait {
+					tick = rt.timeoutTick()
+				}
 				if rt.ThreadID == 0 {
 					select {
 					case err = <-rt.Owner.ChError:
@@ -1416,6 +1576,7 @@ main:
 						if x == ThCmdContinue {
 							rt.setStatus(ThWork)
 						}
+					case <-tick:
 					}
 				} else {
 					select {
@@ -1426,6 +1587,7 @@ main:
 						case ThCmdClose:
 							rt.setStatus(ThClosed)
 						}
+					case <-tick:
 					}
 				}
 			}
@@ -1435,6 +1597,90 @@ main:
 	return
 }
 
+// actualTimeouts removes the deadlines of the finished timeout blocks
+func (rt *Runtime) actualTimeouts() {
+	for k := len(rt.Timeouts) - 1; k >= 0; k-- {
+		item := rt.Timeouts[k]
+		if len(rt.Calls) >= item.Depth && rt.Calls[item.Depth-1].Offset == item.Offset {
+			break
+		}
+		rt.Timeouts = rt.Timeouts[:k]
+	}
+}
+
+// remainTimeout returns the remaining time of the nearest deadline in milliseconds.
+// It returns zero once for the expired deadline which is kept until the end of the block.
+func (rt *Runtime) remainTimeout() int64 {
+	rt.actualTimeouts()
+	remain := SleepStep
+	now := rt.Owner.clock()
+	for k, item := range rt.Timeouts {
+		if item.Expired {
+			continue
+		}
+		if item.Deadline <= now {
+			rt.Timeouts[k].Expired = true
+			return 0
+		}
+		if ms := (item.Deadline-now)/int64(time.Millisecond) + 1; ms < remain {
+			remain = ms
+		}
+	}
+	return remain
+}
+
+// isExpired returns true if the deadline of any current timeout block has been exceeded
+func (rt *Runtime) isExpired() (expired bool) {
+	rt.actualTimeouts()
+	now := rt.Owner.clock()
+	for k, item := range rt.Timeouts {
+		if item.Expired || item.Deadline <= now {
+			rt.Timeouts[k].Expired = true
+			expired = true
+		}
+	}
+	return
+}
+
+// timeoutTick returns the channel which fires when the nearest deadline of timeout blocks
+// should be checked. It returns nil if there are no deadlines.
+func (rt *Runtime) timeoutTick() <-chan time.Time {
+	if len(rt.Timeouts) == 0 {
+		return nil
+	}
+	return time.After(time.Duration(rt.remainTimeout()) * time.Millisecond)
+}
+
+// timeoutError returns ErrTimeout if the deadline of timeout block has been exceeded.
+// It is used by the functions which block the thread.
+func (rt *Runtime) timeoutError() error {
+	if len(rt.Timeouts) == 0 || !rt.isExpired() {
+		return nil
+	}
+	return &RuntimeError{ID: ErrTimeout, Message: ErrorText(ErrTimeout)}
+}
+
+// breakWait cancels sleep and waiting for other threads when the deadline has been exceeded
+func (rt *Runtime) breakWait() {
+	rt.Thread.Sleep = 0
+	if rt.Thread.Status != ThWait {
+		return
+	}
+	vm := rt.Owner
+	vm.ThreadMutex.Lock()
+	for _, thread := range vm.Runtimes {
+		notify := thread.Thread.Notify[:0]
+		for _, id := range thread.Thread.Notify {
+			if id != rt.ThreadID {
+				notify = append(notify, id)
+			}
+		}
+		thread.Thread.Notify = notify
+	}
+	rt.Thread.Status = ThWork
+	vm.ThreadMutex.Unlock()
+}
+
 // exit terminates the script execution
 func exit(rt *Runtime, code int64) error {
 	return &RuntimeError{
@@ -1442,3 +1688,26 @@ func exit(rt *Runtime, code int64) error {
 		Message: ErrorText(ErrExit),
 	}
 }
+
+// typeParams returns the types of the parameters and the result of the embedded function.
+// The actual types of the type parameters of generic functions follow the command
+func typeParams(embed *core.Embed, code []core.Bcode, i *int64) ([]uint16, uint16) {
+	var copied bool
+	params, retType := embed.Params, embed.Return
+	for k, par := range embed.Params {
+		if par != core.TYPEPARAM {
+			continue
+		}
+		if !copied {
+			params = append([]uint16{}, embed.Params...)
+			copied = true
+		}
+		*i++
+		params[k] = uint16(code[*i])
+	}
+	if retType == core.TYPEPARAM {
+		*i++
+		retType = uint16(code[*i])
+	}
+	return params, retType
+}
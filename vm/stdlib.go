@@ -1,6 +1,6 @@
 // Code generated by go generate; DO NOT EDIT.
 // This file was generated by github.com/gentee/gentee/vm/generate/generate.go at
-// 2020/09/23 20:53:29 +05
+// 2026/10/19 18:38:26 UTC
 
 package vm
 
@@ -57,43 +57,51 @@ var EmbedFuncs = []core.Embed{
 		Func: AddHoursºTimeInt, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "AppendFile", Pars: "str,buf", Ret: "", Code: 12, 
+	{Name: "After", Pars: "int", Ret: "thread", Code: 12, 
+		Func: AfterºInt, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "After", Pars: "int,fn", Ret: "thread", Code: 13, 
+		Func: AfterºIntFn, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "AppendFile", Pars: "str,buf", Ret: "", Code: 14, 
 		Func: AppendFileºStrBuf, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "AppendFile", Pars: "str,str", Ret: "", Code: 13, 
+	{Name: "AppendFile", Pars: "str,str", Ret: "", Code: 15, 
 		Func: AppendFileºStrStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Arg", Pars: "str", Ret: "str", Code: 14, 
+	{Name: "Arg", Pars: "str", Ret: "str", Code: 16, 
 		Func: ArgºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Arg", Pars: "str,int", Ret: "int", Code: 15, 
+	{Name: "Arg", Pars: "str,int", Ret: "int", Code: 17, 
 		Func: ArgºStrInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Arg", Pars: "str,str", Ret: "str", Code: 16, 
+	{Name: "Arg", Pars: "str,str", Ret: "str", Code: 18, 
 		Func: ArgºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ArgCount", Pars: "", Ret: "int", Code: 17, 
+	{Name: "ArgCount", Pars: "", Ret: "int", Code: 19, 
 		Func: ArgCount, Return: core.TYPEINT, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Args", Pars: "", Ret: "arr.str", Code: 18, 
+	{Name: "Args", Pars: "", Ret: "arr.str", Code: 20, 
 		Func: Args, Return: core.TYPEARR, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Args", Pars: "str", Ret: "arr.str", Code: 19, 
+	{Name: "Args", Pars: "str", Ret: "arr.str", Code: 21, 
 		Func: ArgsºStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ArgsTail", Pars: "", Ret: "arr.str", Code: 20, 
+	{Name: "ArgsTail", Pars: "", Ret: "arr.str", Code: 22, 
 		Func: ArgsTail, Return: core.TYPEARR, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "arr", Pars: "set", Ret: "arr.int", Code: 21, 
+	{Name: "arr", Pars: "set", Ret: "arr.int", Code: 23, 
 		Func: arrºSet, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -109,6 +117,14 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPECHAR, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Assign", Pars: "enum,enum", Ret: "enum", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Assign", Pars: "error,error", Ret: "error", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEERROR,core.TYPEERROR}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "Assign", Pars: "float,float", Ret: "float", Code: core.ASSIGN, 
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
@@ -121,23 +137,23 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,arr*", Ret: "obj", Code: 28, 
+	{Name: "Assign", Pars: "obj,arr*", Ret: "obj", Code: 32, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,bool", Ret: "obj", Code: 29, 
+	{Name: "Assign", Pars: "obj,bool", Ret: "obj", Code: 33, 
 		Func: core.AssignAnyFunc(AssignºObjBool), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,float", Ret: "obj", Code: 30, 
+	{Name: "Assign", Pars: "obj,float", Ret: "obj", Code: 34, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,int", Ret: "obj", Code: 31, 
+	{Name: "Assign", Pars: "obj,int", Ret: "obj", Code: 35, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,map*", Ret: "obj", Code: 32, 
+	{Name: "Assign", Pars: "obj,map*", Ret: "obj", Code: 36, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -145,7 +161,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,str", Ret: "obj", Code: 34, 
+	{Name: "Assign", Pars: "obj,str", Ret: "obj", Code: 38, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -153,11 +169,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "str,bool", Ret: "str", Code: 36, 
+	{Name: "Assign", Pars: "str,bool", Ret: "str", Code: 40, 
 		Func: core.AssignStrFunc(AssignºStrBool), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "str,int", Ret: "str", Code: 37, 
+	{Name: "Assign", Pars: "str,int", Ret: "str", Code: 41, 
 		Func: core.AssignStrFunc(AssignºStrInt), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -173,6 +189,10 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFUNC, 
 		Params: []uint16{core.TYPEFUNC,core.TYPEFUNC}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AssignºIfaceIface", Pars: "iface,iface", Ret: "iface", Code: core.ASSIGNPTR, 
+		Func: nil, Return: core.TYPEIFACE, 
+		Params: []uint16{core.TYPEIFACE,core.TYPEIFACE}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "AssignºMapMap", Pars: "map*,map*", Ret: "map*", Code: core.ASSIGN, 
 		Func: nil, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
@@ -185,79 +205,83 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAddºArr", Pars: "arr*,arr*", Ret: "arr*", Code: 44, 
+	{Name: "AssignAddºArr", Pars: "arr*,arr*", Ret: "arr*", Code: 49, 
 		Func: core.AssignAnyFunc(AssignAddºArr), Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignAdd", Pars: "arr.bool,bool", Ret: "arr.bool", Code: 45, 
+	{Name: "AssignAdd", Pars: "arr.bool,bool", Ret: "arr.bool", Code: 50, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.int,int", Ret: "arr.int", Code: 46, 
+	{Name: "AssignAdd", Pars: "arr.int,int", Ret: "arr.int", Code: 51, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.obj,obj", Ret: "arr.obj", Code: 47, 
+	{Name: "AssignAdd", Pars: "arr.obj,obj", Ret: "arr.obj", Code: 52, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.thread,thread", Ret: "arr.thread", Code: 48, 
+	{Name: "AssignAdd", Pars: "arr.thread,thread", Ret: "arr.thread", Code: 53, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.str,str", Ret: "arr.str", Code: 49, 
+	{Name: "AssignAdd", Pars: "arr.str,str", Ret: "arr.str", Code: 54, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "buf,buf", Ret: "buf", Code: 50, 
+	{Name: "AssignAdd", Pars: "buf,buf", Ret: "buf", Code: 55, 
 		Func: core.AssignAnyFunc(AssignAddºBufBuf), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "buf,char", Ret: "buf", Code: 51, 
+	{Name: "AssignAdd", Pars: "buf,char", Ret: "buf", Code: 56, 
 		Func: core.AssignAnyFunc(AssignAddºBufChar), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "buf,int", Ret: "buf", Code: 52, 
+	{Name: "AssignAdd", Pars: "buf,int", Ret: "buf", Code: 57, 
 		Func: core.AssignAnyFunc(AssignAddºBufInt), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignAdd", Pars: "buf,str", Ret: "buf", Code: 53, 
+	{Name: "AssignAdd", Pars: "buf,str", Ret: "buf", Code: 58, 
 		Func: core.AssignAnyFunc(AssignAddºBufStr), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "float,float", Ret: "float", Code: 54, 
+	{Name: "AssignAdd", Pars: "float,float", Ret: "float", Code: 59, 
 		Func: core.AssignFloatFunc(AssignAddºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "int,int", Ret: "int", Code: 55, 
+	{Name: "AssignAdd", Pars: "int,int", Ret: "int", Code: 60, 
 		Func: core.AssignIntFunc(AssignAddºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "set,set", Ret: "set", Code: 56, 
+	{Name: "AssignAdd", Pars: "set,set", Ret: "set", Code: 61, 
 		Func: core.AssignAnyFunc(AssignAddºSetSet), Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "str,char", Ret: "str", Code: 57, 
+	{Name: "AssignAdd", Pars: "str,char", Ret: "str", Code: 62, 
 		Func: core.AssignStrFunc(AssignAddºStrChar), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "str,str", Ret: "str", Code: 58, 
+	{Name: "AssignAdd", Pars: "str,str", Ret: "str", Code: 63, 
 		Func: core.AssignStrFunc(AssignAddºStrStr), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAddºArrArr", Pars: "arr.arr*,arr*", Ret: "arr.arr*", Code: 59, 
+	{Name: "AssignAddºArrArr", Pars: "arr.arr*,arr*", Ret: "arr.arr*", Code: 64, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAddºArrMap", Pars: "arr.map*,map*", Ret: "arr.map*", Code: 60, 
+	{Name: "AssignAddºArrMap", Pars: "arr.map*,map*", Ret: "arr.map*", Code: 65, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AssignAddºArrFn", Pars: "arr*,fn", Ret: "arr*", Code: 66, 
+		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPESTRUCT, 
+		Params: []uint16{core.TYPESTRUCT,core.TYPEFUNC}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "AssignBitAnd", Pars: "buf,buf", Ret: "buf", Code: core.ASSIGNPTR, 
 		Func: nil, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignBitAnd", Pars: "int,int", Ret: "int", Code: 62, 
+	{Name: "AssignBitAnd", Pars: "int,int", Ret: "int", Code: 68, 
 		Func: core.AssignIntFunc(AssignBitAndºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -281,55 +305,63 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignBitOr", Pars: "int,int", Ret: "int", Code: 68, 
+	{Name: "AssignBitOr", Pars: "int,int", Ret: "int", Code: 74, 
 		Func: core.AssignIntFunc(AssignBitOrºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignBitXor", Pars: "int,int", Ret: "int", Code: 69, 
+	{Name: "AssignBitXor", Pars: "int,int", Ret: "int", Code: 75, 
 		Func: core.AssignIntFunc(AssignBitXorºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignDiv", Pars: "float,float", Ret: "float", Code: 70, 
+	{Name: "AssignDiv", Pars: "float,float", Ret: "float", Code: 76, 
 		Func: core.AssignFloatFunc(AssignDivºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignDiv", Pars: "int,int", Ret: "int", Code: 71, 
+	{Name: "AssignDiv", Pars: "int,int", Ret: "int", Code: 77, 
 		Func: core.AssignIntFunc(AssignDivºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignMod", Pars: "int,int", Ret: "int", Code: 72, 
+	{Name: "AssignMod", Pars: "int,int", Ret: "int", Code: 78, 
 		Func: core.AssignIntFunc(AssignModºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignLShift", Pars: "int,int", Ret: "int", Code: 73, 
+	{Name: "AssignLShift", Pars: "int,int", Ret: "int", Code: 79, 
 		Func: core.AssignIntFunc(AssignLShiftºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignMul", Pars: "float,float", Ret: "float", Code: 74, 
+	{Name: "AssignMul", Pars: "float,float", Ret: "float", Code: 80, 
 		Func: core.AssignFloatFunc(AssignMulºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignMul", Pars: "int,int", Ret: "int", Code: 75, 
+	{Name: "AssignMul", Pars: "int,int", Ret: "int", Code: 81, 
 		Func: core.AssignIntFunc(AssignMulºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignRShift", Pars: "int,int", Ret: "int", Code: 76, 
+	{Name: "AssignRShift", Pars: "int,int", Ret: "int", Code: 82, 
 		Func: core.AssignIntFunc(AssignRShiftºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignSub", Pars: "float,float", Ret: "float", Code: 77, 
+	{Name: "AssignSub", Pars: "float,float", Ret: "float", Code: 83, 
 		Func: core.AssignFloatFunc(AssignSubºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignSub", Pars: "int,int", Ret: "int", Code: 78, 
+	{Name: "AssignSub", Pars: "int,int", Ret: "int", Code: 84, 
 		Func: core.AssignIntFunc(AssignSubºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Base64", Pars: "buf", Ret: "str", Code: 79, 
+	{Name: "AtomicAdd", Pars: "str,int", Ret: "int", Code: 85, 
+		Func: AtomicAddºStrInt, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "AtomicCAS", Pars: "str,int,int", Ret: "bool", Code: 86, 
+		Func: AtomicCASºStrIntInt, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "Base64", Pars: "buf", Ret: "str", Code: 87, 
 		Func: Base64ºBuf, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BaseName", Pars: "str", Ret: "str", Code: 80, 
+	{Name: "BaseName", Pars: "str", Ret: "str", Code: 88, 
 		Func: BaseName, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -337,7 +369,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BitAnd", Pars: "set,set", Ret: "set", Code: 82, 
+	{Name: "BitAnd", Pars: "set,set", Ret: "set", Code: 90, 
 		Func: BitAndºSetSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -345,7 +377,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BitNot", Pars: "set", Ret: "set", Code: 84, 
+	{Name: "BitNot", Pars: "set", Ret: "set", Code: 92, 
 		Func: BitNotºSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -353,7 +385,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BitOr", Pars: "set,set", Ret: "set", Code: 86, 
+	{Name: "BitOr", Pars: "set,set", Ret: "set", Code: 94, 
 		Func: BitOrºSetSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -361,139 +393,187 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "arr*", Ret: "bool", Code: 88, 
+	{Name: "bool", Pars: "arr*", Ret: "bool", Code: 96, 
 		Func: boolºArr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "buf", Ret: "bool", Code: 89, 
+	{Name: "bool", Pars: "buf", Ret: "bool", Code: 97, 
 		Func: boolºBuf, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "float", Ret: "bool", Code: 90, 
+	{Name: "bool", Pars: "float", Ret: "bool", Code: 98, 
 		Func: boolºFloat, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "int", Ret: "bool", Code: 91, 
+	{Name: "bool", Pars: "int", Ret: "bool", Code: 99, 
 		Func: boolºInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "obj", Ret: "bool", Code: 92, 
+	{Name: "bool", Pars: "obj", Ret: "bool", Code: 100, 
 		Func: boolºObj, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "bool", Pars: "obj,bool", Ret: "bool", Code: 93, 
+	{Name: "bool", Pars: "obj,bool", Ret: "bool", Code: 101, 
 		Func: boolºObjDef, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "bool", Pars: "map*", Ret: "bool", Code: 94, 
+	{Name: "bool", Pars: "map*", Ret: "bool", Code: 102, 
 		Func: boolºMap, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "str", Ret: "bool", Code: 95, 
+	{Name: "bool", Pars: "str", Ret: "bool", Code: 103, 
 		Func: boolºStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "buf", Pars: "str", Ret: "buf", Code: 96, 
+	{Name: "buf", Pars: "str", Ret: "buf", Code: 104, 
 		Func: bufºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Ceil", Pars: "float", Ret: "int", Code: 97, 
+	{Name: "CSV", Pars: "arr.arr.str", Ret: "str", Code: 105, 
+		Func: CSVºArr, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEARR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "CSV", Pars: "arr.arr.str,str", Ret: "str", Code: 106, 
+		Func: CSVºArrStr, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEARR,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Ceil", Pars: "float", Ret: "int", Code: 107, 
 		Func: CeilºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ChDir", Pars: "str", Ret: "", Code: 98, 
+	{Name: "ChDir", Pars: "str", Ret: "", Code: 108, 
 		Func: ChDirºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ChMode", Pars: "str,int", Ret: "", Code: 99, 
+	{Name: "ChMode", Pars: "str,int", Ret: "", Code: 109, 
 		Func: ChModeºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ClearCarriage", Pars: "str", Ret: "str", Code: 100, 
+	{Name: "ClearCarriage", Pars: "str", Ret: "str", Code: 110, 
 		Func: ClearCarriage, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Command", Pars: "str", Ret: "", Code: 101, 
+	{Name: "Command", Pars: "str", Ret: "", Code: 111, 
 		Func: Command, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CommandOutput", Pars: "str", Ret: "str", Code: 102, 
+	{Name: "CommandOutput", Pars: "str", Ret: "str", Code: 112, 
 		Func: CommandOutput, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CopyFile", Pars: "str,str", Ret: "int", Code: 103, 
+	{Name: "Compress", Pars: "buf,str", Ret: "buf", Code: 113, 
+		Func: CompressºBufStr, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEBUF,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "CompressFile", Pars: "str,str,str", Ret: "", Code: 114, 
+		Func: CompressFileºStrStrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Contains<T>", Pars: "arr.T,T", Ret: "bool", Code: 115, 
+		Func: ContainsºArr, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEARR,core.TYPEPARAM}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "CopyFile", Pars: "str,str", Ret: "int", Code: 116, 
 		Func: CopyFileºStrStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CreateDir", Pars: "str", Ret: "", Code: 104, 
+	{Name: "CreateDir", Pars: "str", Ret: "", Code: 117, 
 		Func: CreateDirºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CreateFile", Pars: "str,bool", Ret: "", Code: 105, 
+	{Name: "CreateFile", Pars: "str,bool", Ret: "", Code: 118, 
 		Func: CreateFileºStrBool, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Ctx", Pars: "str", Ret: "str", Code: 106, 
+	{Name: "Ctx", Pars: "str", Ret: "str", Code: 119, 
 		Func: CtxºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxGet", Pars: "str", Ret: "str", Code: 107, 
+	{Name: "CtxGet", Pars: "str", Ret: "str", Code: 120, 
 		Func: CtxGetºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxIs", Pars: "str", Ret: "bool", Code: 108, 
+	{Name: "CtxIs", Pars: "str", Ret: "bool", Code: 121, 
 		Func: CtxIsºStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "CtxSet", Pars: "str,bool", Ret: "str", Code: 109, 
+	{Name: "CtxSet", Pars: "str,bool", Ret: "str", Code: 122, 
 		Func: CtxSetºStrBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxSet", Pars: "str,float", Ret: "str", Code: 110, 
+	{Name: "CtxSet", Pars: "str,float", Ret: "str", Code: 123, 
 		Func: CtxSetºStrFloat, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxSet", Pars: "str,int", Ret: "str", Code: 111, 
+	{Name: "CtxSet", Pars: "str,int", Ret: "str", Code: 124, 
 		Func: CtxSetºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxSet", Pars: "str,str", Ret: "str", Code: 112, 
+	{Name: "CtxSet", Pars: "str,str", Ret: "str", Code: 125, 
 		Func: CtxSetºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxValue", Pars: "str", Ret: "str", Code: 113, 
+	{Name: "CtxValue", Pars: "str", Ret: "str", Code: 126, 
 		Func: CtxValueºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Date", Pars: "int,int,int", Ret: "time", Code: 114, 
+	{Name: "Date", Pars: "int,int,int", Ret: "time", Code: 127, 
 		Func: DateºInts, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "DateTime", Pars: "int,int,int,int,int,int", Ret: "time", Code: 115, 
+	{Name: "DateTime", Pars: "int,int,int,int,int,int", Ret: "time", Code: 128, 
 		Func: DateTimeºInts, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT,core.TYPEINT,core.TYPEINT,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Days", Pars: "time", Ret: "int", Code: 116, 
+	{Name: "Days", Pars: "time", Ret: "int", Code: 129, 
 		Func: DaysºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Del", Pars: "buf,int,int", Ret: "buf", Code: 117, 
+	{Name: "Decompress", Pars: "buf,str", Ret: "buf", Code: 130, 
+		Func: DecompressºBufStr, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEBUF,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "DecompressFile", Pars: "str,str,str", Ret: "", Code: 131, 
+		Func: DecompressFileºStrStrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Decrypt", Pars: "buf,buf", Ret: "buf", Code: 132, 
+		Func: DecryptºBufBuf, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "DecryptFile", Pars: "str,str,buf", Ret: "", Code: 133, 
+		Func: DecryptFileºStrStrBuf, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEBUF}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Del", Pars: "buf,int,int", Ret: "buf", Code: 134, 
 		Func: DelºBufIntInt, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "DelAuto", Pars: "map*,str", Ret: "map*", Code: 118, 
-		Func: DelºMapStr, Return: core.TYPESTRUCT, 
-		Params: []uint16{core.TYPESTRUCT,core.TYPESTR}, 
+	{Name: "Del<T>", Pars: "map.T,str", Ret: "map.T", Code: 135, 
+		Func: DelºMapStr, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPEMAP,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Del<T>", Pars: "map[int].T,int", Ret: "map[int].T", Code: 136, 
+		Func: DelºIntMapInt, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPEMAP,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Dir", Pars: "str", Ret: "str", Code: 119, 
+	{Name: "Delete<T>", Pars: "arr.T,int", Ret: "arr.T", Code: 137, 
+		Func: DeleteºArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "DeriveKey", Pars: "str,str,buf,int", Ret: "buf", Code: 138, 
+		Func: DeriveKeyºStrStrBufInt, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEBUF,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Dir", Pars: "str", Ret: "str", Code: 139, 
 		Func: Dir, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Download", Pars: "str,str", Ret: "int", Code: 120, 
+	{Name: "Download", Pars: "str,str", Ret: "int", Code: 140, 
 		Func: Download, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Ext", Pars: "str", Ret: "str", Code: 121, 
+	{Name: "Ext", Pars: "str", Ret: "str", Code: 141, 
 		Func: Ext, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -501,11 +581,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Div", Pars: "float,int", Ret: "float", Code: 123, 
+	{Name: "Div", Pars: "float,int", Ret: "float", Code: 143, 
 		Func: DivºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Div", Pars: "int,float", Ret: "float", Code: 124, 
+	{Name: "Div", Pars: "int,float", Ret: "float", Code: 144, 
 		Func: DivºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
@@ -513,15 +593,31 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Encrypt", Pars: "buf,buf", Ret: "buf", Code: 146, 
+		Func: EncryptºBufBuf, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "EncryptFile", Pars: "str,str,buf", Ret: "", Code: 147, 
+		Func: EncryptFileºStrStrBuf, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEBUF}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Equal", Pars: "buf,buf", Ret: "bool", Code: 148, 
+		Func: EqualºBufBuf, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "Equal", Pars: "char,char", Ret: "bool", Code: core.EQ, 
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Equal", Pars: "enum,enum", Ret: "bool", Code: core.EQ, 
+		Func: nil, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "Equal", Pars: "float,float", Ret: "bool", Code: core.EQFLOAT, 
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Equal", Pars: "float,int", Ret: "bool", Code: 128, 
+	{Name: "Equal", Pars: "float,int", Ret: "bool", Code: 152, 
 		Func: EqualºFloatInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -533,51 +629,63 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Equal", Pars: "time,time", Ret: "bool", Code: 131, 
+	{Name: "Equal", Pars: "time,time", Ret: "bool", Code: 155, 
 		Func: EqualºTimeTime, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ErrID", Pars: "error", Ret: "int", Code: 132, 
+	{Name: "ErrData", Pars: "error", Ret: "obj", Code: 156, 
+		Func: ErrData, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPEERROR}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "ErrID", Pars: "error", Ret: "int", Code: 157, 
 		Func: ErrID, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEERROR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "error", Pars: "int,str", Ret: "", Code: 133, 
+	{Name: "ErrOf", Pars: "thread", Ret: "error", Code: 158, 
+		Func: ErrOfºThread, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "error", Pars: "int,str", Ret: "", Code: 159, 
 		Func: errorºIntStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT,core.TYPESTR}, 
 		Variadic: true, Runtime: false, CanError: true},
-	{Name: "ErrText", Pars: "error", Ret: "str", Code: 134, 
+	{Name: "error", Pars: "error", Ret: "", Code: 160, 
+		Func: errorºError, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPEERROR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ErrText", Pars: "error", Ret: "str", Code: 161, 
 		Func: ErrText, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEERROR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ErrTrace", Pars: "error", Ret: "arr.trace", Code: 135, 
+	{Name: "ErrTrace", Pars: "error", Ret: "arr.trace", Code: 162, 
 		Func: ErrTrace, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEERROR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ExistFile", Pars: "str", Ret: "bool", Code: 136, 
+	{Name: "ExistFile", Pars: "str", Ret: "bool", Code: 163, 
 		Func: ExistFile, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "exit", Pars: "int", Ret: "", Code: 137, 
+	{Name: "exit", Pars: "int", Ret: "", Code: 164, 
 		Func: exit, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ExpStr", Pars: "str,bool", Ret: "str", Code: 138, 
+	{Name: "ExpStr", Pars: "str,bool", Ret: "str", Code: 165, 
 		Func: ExpStrºBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,char", Ret: "str", Code: 139, 
+	{Name: "ExpStr", Pars: "str,char", Ret: "str", Code: 166, 
 		Func: ExpStrºChar, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,float", Ret: "str", Code: 140, 
+	{Name: "ExpStr", Pars: "str,float", Ret: "str", Code: 167, 
 		Func: ExpStrºFloat, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,int", Ret: "str", Code: 141, 
+	{Name: "ExpStr", Pars: "str,int", Ret: "str", Code: 168, 
 		Func: ExpStrºInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,obj", Ret: "str", Code: 142, 
+	{Name: "ExpStr", Pars: "str,obj", Ret: "str", Code: 169, 
 		Func: ExpStrºObj, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -585,67 +693,75 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "FileInfo", Pars: "str", Ret: "finfo", Code: 144, 
+	{Name: "FileInfo", Pars: "str", Ret: "finfo", Code: 171, 
 		Func: FileInfoºStr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "FileMode", Pars: "str", Ret: "int", Code: 145, 
+	{Name: "FileMode", Pars: "str", Ret: "int", Code: 172, 
 		Func: FileModeºStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Find", Pars: "str,str", Ret: "int", Code: 146, 
+	{Name: "Filter<T>", Pars: "arr.T,fn.T.bool", Ret: "arr.T", Code: 173, 
+		Func: FilterºArrFn, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Find", Pars: "str,str", Ret: "int", Code: 174, 
 		Func: FindºStrStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "FindRegExp", Pars: "str,str", Ret: "arr.arr.str", Code: 147, 
+	{Name: "FindRegExp", Pars: "str,str", Ret: "arr.arr.str", Code: 175, 
 		Func: FindRegExpºStrStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "float", Pars: "int", Ret: "float", Code: 148, 
+	{Name: "float", Pars: "int", Ret: "float", Code: 176, 
 		Func: floatºInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "float", Pars: "obj", Ret: "float", Code: 149, 
+	{Name: "float", Pars: "obj", Ret: "float", Code: 177, 
 		Func: floatºObj, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "float", Pars: "obj,float", Ret: "float", Code: 150, 
+	{Name: "float", Pars: "obj,float", Ret: "float", Code: 178, 
 		Func: floatºObjDef, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "float", Pars: "str", Ret: "float", Code: 151, 
+	{Name: "float", Pars: "str", Ret: "float", Code: 179, 
 		Func: floatºStr, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Floor", Pars: "float", Ret: "int", Code: 152, 
+	{Name: "Floor", Pars: "float", Ret: "int", Code: 180, 
 		Func: FloorºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Format", Pars: "str", Ret: "str", Code: 153, 
+	{Name: "Format", Pars: "str", Ret: "str", Code: 181, 
 		Func: FormatºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: true, Runtime: false, CanError: false},
-	{Name: "Format", Pars: "str,time", Ret: "str", Code: 154, 
+	{Name: "Format", Pars: "str,time", Ret: "str", Code: 182, 
 		Func: FormatºTimeStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "GetCurDir", Pars: "", Ret: "str", Code: 155, 
+	{Name: "GetCurDir", Pars: "", Ret: "str", Code: 183, 
 		Func: GetCurDir, Return: core.TYPESTR, 
 		Params: nil, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "GetEnv", Pars: "str", Ret: "str", Code: 156, 
+	{Name: "GetEnv", Pars: "str", Ret: "str", Code: 184, 
 		Func: GetEnv, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Greater", Pars: "char,char", Ret: "bool", Code: 157, 
+	{Name: "Greater", Pars: "char,char", Ret: "bool", Code: 185, 
 		Func: GreaterºCharChar, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Greater", Pars: "enum,enum", Ret: "bool", Code: core.GT, 
+		Func: nil, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "Greater", Pars: "float,float", Ret: "bool", Code: core.GTFLOAT, 
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Greater", Pars: "float,int", Ret: "bool", Code: 159, 
+	{Name: "Greater", Pars: "float,int", Ret: "bool", Code: 188, 
 		Func: GreaterºFloatInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -657,54 +773,82 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Greater", Pars: "time,time", Ret: "bool", Code: 162, 
+	{Name: "Greater", Pars: "time,time", Ret: "bool", Code: 191, 
 		Func: GreaterºTimeTime, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HasPrefix", Pars: "str,str", Ret: "bool", Code: 163, 
+	{Name: "Gzip", Pars: "buf", Ret: "buf", Code: 192, 
+		Func: GzipºBuf, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEBUF}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Gzip", Pars: "str,str", Ret: "", Code: 193, 
+		Func: GzipºStrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Hash", Pars: "str,buf", Ret: "buf", Code: 194, 
+		Func: HashºStrBuf, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "HashFile", Pars: "str,str", Ret: "str", Code: 195, 
+		Func: HashFileºStrStr, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "HasPrefix", Pars: "str,str", Ret: "bool", Code: 196, 
 		Func: HasPrefixºStrStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HasSuffix", Pars: "str,str", Ret: "bool", Code: 164, 
+	{Name: "HasSuffix", Pars: "str,str", Ret: "bool", Code: 197, 
 		Func: HasSuffixºStrStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Hex", Pars: "buf", Ret: "str", Code: 165, 
+	{Name: "Hex", Pars: "buf", Ret: "str", Code: 198, 
 		Func: HexºBuf, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HTTPGet", Pars: "str", Ret: "buf", Code: 166, 
+	{Name: "Hmac", Pars: "str,buf,buf", Ret: "buf", Code: 199, 
+		Func: HmacºStrBufBuf, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPESTR,core.TYPEBUF,core.TYPEBUF}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "HTTPGet", Pars: "str", Ret: "buf", Code: 200, 
 		Func: HTTPGet, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "HTTPPage", Pars: "str", Ret: "str", Code: 167, 
+	{Name: "HTTPPage", Pars: "str", Ret: "str", Code: 201, 
 		Func: HTTPPage, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "HTTPRequest", Pars: "str,str,map.str,map.str", Ret: "str", Code: 168, 
+	{Name: "HTTPRequest", Pars: "str,str,map.str,map.str", Ret: "str", Code: 202, 
 		Func: HTTPRequest, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEMAP,core.TYPEMAP}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Join", Pars: "arr.str,str", Ret: "str", Code: 169, 
+	{Name: "Join", Pars: "arr.str,str", Ret: "str", Code: 203, 
 		Func: JoinºArrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "JoinPath", Pars: "", Ret: "str", Code: 170, 
+	{Name: "JoinPath", Pars: "", Ret: "str", Code: 204, 
 		Func: JoinPath, Return: core.TYPESTR, 
 		Params: nil, 
 		Variadic: true, Runtime: false, CanError: false},
-	{Name: "Json", Pars: "obj", Ret: "str", Code: 171, 
+	{Name: "Json", Pars: "obj", Ret: "str", Code: 205, 
 		Func: Json, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "JsonToObj", Pars: "str", Ret: "obj", Code: 172, 
+	{Name: "JsonToObj", Pars: "str", Ret: "obj", Code: 206, 
 		Func: JsonToObj, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Insert", Pars: "buf,int,buf", Ret: "buf", Code: 173, 
+	{Name: "IndexOf<T>", Pars: "arr.T,T", Ret: "int", Code: 207, 
+		Func: IndexOfºArr, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEARR,core.TYPEPARAM}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Insert", Pars: "buf,int,buf", Ret: "buf", Code: 208, 
 		Func: InsertºBufIntBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Insert<T>", Pars: "arr.T,int,T", Ret: "arr.T", Code: 209, 
+		Func: InsertºArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEINT,core.TYPEPARAM}, 
+		Variadic: false, Runtime: true, CanError: true},
 	{Name: "int", Pars: "bool", Ret: "int", Code: core.NOP, 
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEBOOL}, 
@@ -713,51 +857,79 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "int", Pars: "float", Ret: "int", Code: 176, 
+	{Name: "int", Pars: "enum", Ret: "int", Code: core.NOP, 
+		Func: nil, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "int", Pars: "float", Ret: "int", Code: 213, 
 		Func: intºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "int", Pars: "obj", Ret: "int", Code: 177, 
+	{Name: "int", Pars: "obj", Ret: "int", Code: 214, 
 		Func: intºObj, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "int", Pars: "obj,int", Ret: "int", Code: 178, 
+	{Name: "int", Pars: "obj,int", Ret: "int", Code: 215, 
 		Func: intºObjDef, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "int", Pars: "str", Ret: "int", Code: 179, 
+	{Name: "int", Pars: "str", Ret: "int", Code: 216, 
 		Func: intºStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "int", Pars: "time", Ret: "int", Code: 180, 
+	{Name: "int", Pars: "time", Ret: "int", Code: 217, 
 		Func: intºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "IsArg", Pars: "str", Ret: "bool", Code: 181, 
+	{Name: "Is", Pars: "error,int", Ret: "bool", Code: 218, 
+		Func: IsºErrorInt, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEERROR,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "IsArg", Pars: "str", Ret: "bool", Code: 219, 
 		Func: IsArgºStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "IsKeyAuto", Pars: "map*,str", Ret: "bool", Code: 182, 
+	{Name: "IsKey<T>", Pars: "map.T,str", Ret: "bool", Code: 220, 
 		Func: IsKeyºMapStr, Return: core.TYPEBOOL, 
-		Params: []uint16{core.TYPESTRUCT,core.TYPESTR}, 
+		Params: []uint16{core.TYPEMAP,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "IsKey<T>", Pars: "map[int].T,int", Ret: "bool", Code: 221, 
+		Func: IsKeyºIntMapInt, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEMAP,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "IsMap", Pars: "obj", Ret: "bool", Code: 222, 
+		Func: IsMap, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "IsNil", Pars: "obj", Ret: "bool", Code: 183, 
+	{Name: "IsNil", Pars: "obj", Ret: "bool", Code: 223, 
 		Func: IsNil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "item", Pars: "obj,int", Ret: "obj", Code: 184, 
+	{Name: "item", Pars: "obj,int", Ret: "obj", Code: 224, 
 		Func: itemºObjInt, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "item", Pars: "obj,str", Ret: "obj", Code: 185, 
+	{Name: "item", Pars: "obj,str", Ret: "obj", Code: 225, 
 		Func: itemºObjStr, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "KeyAuto", Pars: "map*,int", Ret: "str", Code: 186, 
+	{Name: "Key<T>", Pars: "map.T,int", Ret: "str", Code: 226, 
 		Func: KeyºMapInt, Return: core.TYPESTR, 
-		Params: []uint16{core.TYPESTRUCT,core.TYPEINT}, 
+		Params: []uint16{core.TYPEMAP,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Key<T>", Pars: "map[int].T,int", Ret: "int", Code: 227, 
+		Func: KeyºIntMapInt, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEMAP,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Left", Pars: "str,int", Ret: "str", Code: 187, 
+	{Name: "Keys<T>", Pars: "map.T", Ret: "arr.str", Code: 228, 
+		Func: KeysºMap, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEMAP}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Keys<T>", Pars: "map[int].T", Ret: "arr.int", Code: 229, 
+		Func: KeysºIntMap, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEMAP}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Left", Pars: "str,int", Ret: "str", Code: 230, 
 		Func: LeftºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -785,15 +957,19 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Less", Pars: "char,char", Ret: "bool", Code: 194, 
+	{Name: "Less", Pars: "char,char", Ret: "bool", Code: 237, 
 		Func: LessºCharChar, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Less", Pars: "enum,enum", Ret: "bool", Code: core.LT, 
+		Func: nil, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "Less", Pars: "float,float", Ret: "bool", Code: core.LTFLOAT, 
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Less", Pars: "float,int", Ret: "bool", Code: 196, 
+	{Name: "Less", Pars: "float,int", Ret: "bool", Code: 240, 
 		Func: LessºFloatInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -805,19 +981,27 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Less", Pars: "time,time", Ret: "bool", Code: 199, 
+	{Name: "Less", Pars: "time,time", Ret: "bool", Code: 243, 
 		Func: LessºTimeTime, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Lines", Pars: "str", Ret: "arr.str", Code: 200, 
+	{Name: "Lines", Pars: "str", Ret: "arr.str", Code: 244, 
 		Func: LinesºStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Lock", Pars: "", Ret: "", Code: 201, 
+	{Name: "LoadEnvFile", Pars: "str", Ret: "", Code: 245, 
+		Func: LoadEnvFile, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Lock", Pars: "", Ret: "", Code: 246, 
 		Func: Lock, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Lower", Pars: "str", Ret: "str", Code: 202, 
+	{Name: "Lock", Pars: "str", Ret: "", Code: 247, 
+		Func: LockºStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Lower", Pars: "str", Ret: "str", Code: 248, 
 		Func: LowerºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -825,39 +1009,51 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Match", Pars: "str,str", Ret: "bool", Code: 204, 
+	{Name: "Map<T,R>", Pars: "arr.T,fn.T.R", Ret: "arr.R", Code: 250, 
+		Func: MapºArrFn, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Match", Pars: "str,str", Ret: "bool", Code: 251, 
 		Func: MatchºStrStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "MatchPath", Pars: "str,str", Ret: "bool", Code: 205, 
+	{Name: "MatchPath", Pars: "str,str", Ret: "bool", Code: 252, 
 		Func: MatchPath, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Max", Pars: "float,float", Ret: "float", Code: 206, 
+	{Name: "Max", Pars: "float,float", Ret: "float", Code: 253, 
 		Func: MaxºFloatFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Max", Pars: "int,int", Ret: "int", Code: 207, 
+	{Name: "Max", Pars: "int,int", Ret: "int", Code: 254, 
 		Func: MaxºIntInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Md5", Pars: "buf", Ret: "buf", Code: 208, 
+	{Name: "Md5", Pars: "buf", Ret: "buf", Code: 255, 
 		Func: Md5ºBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Md5", Pars: "str", Ret: "buf", Code: 209, 
+	{Name: "Md5", Pars: "str", Ret: "buf", Code: 256, 
 		Func: Md5ºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Md5File", Pars: "str", Ret: "str", Code: 210, 
+	{Name: "Md5File", Pars: "str", Ret: "str", Code: 257, 
 		Func: Md5FileºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Min", Pars: "float,float", Ret: "float", Code: 211, 
+	{Name: "Merge<T>", Pars: "map.T,map.T", Ret: "map.T", Code: 258, 
+		Func: MergeºMapMap, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPEMAP,core.TYPEMAP}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "Merge<T>", Pars: "map[int].T,map[int].T", Ret: "map[int].T", Code: 259, 
+		Func: MergeºMapMap, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPEMAP,core.TYPEMAP}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "Min", Pars: "float,float", Ret: "float", Code: 260, 
 		Func: MinºFloatFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Min", Pars: "int,int", Ret: "int", Code: 212, 
+	{Name: "Min", Pars: "int,int", Ret: "int", Code: 261, 
 		Func: MinºIntInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -869,11 +1065,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Mul", Pars: "float,int", Ret: "float", Code: 215, 
+	{Name: "Mul", Pars: "float,int", Ret: "float", Code: 264, 
 		Func: MulºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Mul", Pars: "int,float", Ret: "float", Code: 216, 
+	{Name: "Mul", Pars: "int,float", Ret: "float", Code: 265, 
 		Func: MulºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -881,6 +1077,14 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "NewError", Pars: "int,str", Ret: "error", Code: 267, 
+		Func: NewErrorºIntStr, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "NewError", Pars: "int,str,obj", Ret: "error", Code: 268, 
+		Func: NewErrorºIntStrObj, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEINT,core.TYPESTR,core.TYPEOBJ}, 
+		Variadic: false, Runtime: true, CanError: true},
 	{Name: "NewKeyValue", Pars: "int,int", Ret: "keyval", Code: core.NOP, 
 		Func: nil, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
@@ -893,131 +1097,183 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Now", Pars: "", Ret: "time", Code: 221, 
+	{Name: "Now", Pars: "", Ret: "time", Code: 272, 
 		Func: Now, Return: core.TYPESTRUCT, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "obj", Pars: "arr*", Ret: "obj", Code: 222, 
+	{Name: "obj", Pars: "arr*", Ret: "obj", Code: 273, 
 		Func: objºArrMap, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "obj", Pars: "bool", Ret: "obj", Code: 223, 
+	{Name: "obj", Pars: "bool", Ret: "obj", Code: 274, 
 		Func: objºBool, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "float", Ret: "obj", Code: 224, 
+	{Name: "obj", Pars: "float", Ret: "obj", Code: 275, 
 		Func: objºAny, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "int", Ret: "obj", Code: 225, 
+	{Name: "obj", Pars: "int", Ret: "obj", Code: 276, 
 		Func: objºAny, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "map*", Ret: "obj", Code: 226, 
+	{Name: "obj", Pars: "map*", Ret: "obj", Code: 277, 
 		Func: objºArrMap, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "obj", Pars: "str", Ret: "obj", Code: 227, 
+	{Name: "obj", Pars: "str", Ret: "obj", Code: 278, 
 		Func: objºAny, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Open", Pars: "str", Ret: "", Code: 228, 
+	{Name: "Open", Pars: "str", Ret: "", Code: 279, 
 		Func: OpenºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "OpenWith", Pars: "str,str", Ret: "", Code: 229, 
+	{Name: "OpenWith", Pars: "str,str", Ret: "", Code: 280, 
 		Func: OpenWithºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ParseTime", Pars: "str,str", Ret: "time", Code: 230, 
+	{Name: "ParallelFor", Pars: "arr*,int,fn", Ret: "arr.obj", Code: 281, 
+		Func: ParallelForºArrIntFn, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTRUCT,core.TYPEINT,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ParallelFor", Pars: "arr*,int,fn,bool", Ret: "arr.obj", Code: 282, 
+		Func: ParallelForºArrIntFnBool, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTRUCT,core.TYPEINT,core.TYPEFUNC,core.TYPEBOOL}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ParseCSV", Pars: "str", Ret: "arr.arr.str", Code: 283, 
+		Func: ParseCSVºStr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ParseCSV", Pars: "str,str", Ret: "arr.arr.str", Code: 284, 
+		Func: ParseCSVºStrStr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ParseCSV", Pars: "str,str,arr.map.str", Ret: "arr.map.str", Code: 285, 
+		Func: ParseCSVºStrStrArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEARR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ParseTime", Pars: "str,str", Ret: "time", Code: 286, 
 		Func: ParseTimeºStrStr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Print", Pars: "", Ret: "int", Code: 231, 
+	{Name: "Print", Pars: "", Ret: "int", Code: 287, 
 		Func: Print, Return: core.TYPEINT, 
 		Params: nil, 
 		Variadic: true, Runtime: false, CanError: true},
-	{Name: "Println", Pars: "", Ret: "int", Code: 232, 
+	{Name: "Println", Pars: "", Ret: "int", Code: 288, 
 		Func: Println, Return: core.TYPEINT, 
 		Params: nil, 
 		Variadic: true, Runtime: false, CanError: true},
-	{Name: "PrintShift", Pars: "str", Ret: "int", Code: 233, 
+	{Name: "PrintShift", Pars: "str", Ret: "int", Code: 289, 
 		Func: PrintShiftºStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Random", Pars: "int", Ret: "int", Code: 234, 
+	{Name: "Random", Pars: "int", Ret: "int", Code: 290, 
 		Func: Random, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ReadDir", Pars: "str", Ret: "arr.finfo", Code: 235, 
+	{Name: "RandomBytes", Pars: "int", Ret: "buf", Code: 291, 
+		Func: RandomBytesºInt, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ReadCSV", Pars: "str,str", Ret: "arr.arr.str", Code: 292, 
+		Func: ReadCSVºStrStr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReadCSV", Pars: "str,str,arr.map.str", Ret: "arr.map.str", Code: 293, 
+		Func: ReadCSVºStrStrArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEARR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReadDir", Pars: "str", Ret: "arr.finfo", Code: 294, 
 		Func: ReadDirºStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadDir", Pars: "str,int,str", Ret: "arr.finfo", Code: 236, 
+	{Name: "ReadDir", Pars: "str,int,str", Ret: "arr.finfo", Code: 295, 
 		Func: ReadDirºStrIntStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadFile", Pars: "str", Ret: "str", Code: 237, 
+	{Name: "ReadFile", Pars: "str", Ret: "str", Code: 296, 
 		Func: ReadFileºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadFile", Pars: "str,buf", Ret: "buf", Code: 238, 
+	{Name: "ReadFile", Pars: "str,buf", Ret: "buf", Code: 297, 
 		Func: ReadFileºStrBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadFile", Pars: "str,int,int", Ret: "buf", Code: 239, 
+	{Name: "ReadFile", Pars: "str,int,int", Ret: "buf", Code: 298, 
 		Func: ReadFileºStrIntInt, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadString", Pars: "str", Ret: "str", Code: 240, 
+	{Name: "ReadIni", Pars: "str", Ret: "map.map.str", Code: 299, 
+		Func: ReadIni, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReadString", Pars: "str", Ret: "str", Code: 300, 
 		Func: ReadString, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "RegExp", Pars: "str,str", Ret: "str", Code: 241, 
+	{Name: "Reduce<T,R>", Pars: "arr.T,fn.R.T.R,R", Ret: "R", Code: 301, 
+		Func: ReduceºArrFn, Return: core.TYPEPARAM, 
+		Params: []uint16{core.TYPEARR,core.TYPEFUNC,core.TYPEPARAM}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "RegExp", Pars: "str,str", Ret: "str", Code: 302, 
 		Func: RegExpºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Remove", Pars: "str", Ret: "", Code: 242, 
+	{Name: "Remove", Pars: "str", Ret: "", Code: 303, 
 		Func: RemoveºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "RemoveDir", Pars: "str", Ret: "", Code: 243, 
+	{Name: "RemoveDir", Pars: "str", Ret: "", Code: 304, 
 		Func: RemoveDirºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Rename", Pars: "str,str", Ret: "", Code: 244, 
+	{Name: "Rename", Pars: "str,str", Ret: "", Code: 305, 
 		Func: RenameºStrStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Repeat", Pars: "str,int", Ret: "str", Code: 245, 
+	{Name: "Repeat", Pars: "str,int", Ret: "str", Code: 306, 
 		Func: RepeatºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Replace", Pars: "str,str,str", Ret: "str", Code: 246, 
+	{Name: "Result", Pars: "thread", Ret: "obj", Code: 307, 
+		Func: ResultºThread, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Result<T>", Pars: "thread.T", Ret: "T", Code: 308, 
+		Func: ResultºTypedThread, Return: core.TYPEPARAM, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Replace", Pars: "str,str,str", Ret: "str", Code: 309, 
 		Func: ReplaceºStrStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ReplaceRegExp", Pars: "str,str,str", Ret: "str", Code: 247, 
+	{Name: "ReplaceRegExp", Pars: "str,str,str", Ret: "str", Code: 310, 
 		Func: ReplaceRegExpºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "ReverseAuto", Pars: "arr*", Ret: "arr*", Code: 248, 
-		Func: ReverseºArr, Return: core.TYPESTRUCT, 
-		Params: []uint16{core.TYPESTRUCT}, 
+	{Name: "Reverse<T>", Pars: "arr.T", Ret: "arr.T", Code: 311, 
+		Func: ReverseºArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "resume", Pars: "thread", Ret: "", Code: 249, 
+	{Name: "resume", Pars: "thread", Ret: "", Code: 312, 
 		Func: resumeºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Right", Pars: "str,int", Ret: "str", Code: 250, 
+	{Name: "Right", Pars: "str,int", Ret: "str", Code: 313, 
 		Func: RightºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Round", Pars: "float", Ret: "int", Code: 251, 
+	{Name: "RLock", Pars: "str", Ret: "", Code: 314, 
+		Func: RLockºStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Round", Pars: "float", Ret: "int", Code: 315, 
 		Func: RoundºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Round", Pars: "float,int", Ret: "float", Code: 252, 
+	{Name: "Round", Pars: "float,int", Ret: "float", Code: 316, 
 		Func: RoundºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1025,47 +1281,51 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "set", Pars: "arr.int", Ret: "set", Code: 254, 
+	{Name: "RUnlock", Pars: "str", Ret: "", Code: 318, 
+		Func: RUnlockºStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "set", Pars: "arr.int", Ret: "set", Code: 319, 
 		Func: setºArr, Return: core.TYPESET, 
 		Params: []uint16{core.TYPEARR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Set", Pars: "set,int", Ret: "set", Code: 255, 
+	{Name: "Set", Pars: "set,int", Ret: "set", Code: 320, 
 		Func: SetºSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "set", Pars: "str", Ret: "set", Code: 256, 
+	{Name: "set", Pars: "str", Ret: "set", Code: 321, 
 		Func: setºStr, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "SetEnv", Pars: "str,str", Ret: "str", Code: 257, 
+	{Name: "SetEnv", Pars: "str,str", Ret: "str", Code: 322, 
 		Func: SetEnv, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetEnv", Pars: "str,int", Ret: "str", Code: 258, 
+	{Name: "SetEnv", Pars: "str,int", Ret: "str", Code: 323, 
 		Func: SetEnv, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetEnv", Pars: "str,bool", Ret: "str", Code: 259, 
+	{Name: "SetEnv", Pars: "str,bool", Ret: "str", Code: 324, 
 		Func: SetEnvBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetFileTime", Pars: "str,time", Ret: "", Code: 260, 
+	{Name: "SetFileTime", Pars: "str,time", Ret: "", Code: 325, 
 		Func: SetFileTimeºStrTime, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Sha256", Pars: "buf", Ret: "buf", Code: 261, 
+	{Name: "Sha256", Pars: "buf", Ret: "buf", Code: 326, 
 		Func: Sha256ºBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sha256", Pars: "str", Ret: "buf", Code: 262, 
+	{Name: "Sha256", Pars: "str", Ret: "buf", Code: 327, 
 		Func: Sha256ºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sha256File", Pars: "str", Ret: "str", Code: 263, 
+	{Name: "Sha256File", Pars: "str", Ret: "str", Code: 328, 
 		Func: Sha256FileºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Shift", Pars: "str", Ret: "str", Code: 264, 
+	{Name: "Shift", Pars: "str", Ret: "str", Code: 329, 
 		Func: ShiftºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1077,55 +1337,75 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "sleep", Pars: "int", Ret: "", Code: 267, 
+	{Name: "sleep", Pars: "int", Ret: "", Code: 332, 
 		Func: sleepºInt, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "SliceAuto", Pars: "arr*,int,int", Ret: "arr*", Code: 268, 
-		Func: SliceºArr, Return: core.TYPESTRUCT, 
-		Params: []uint16{core.TYPESTRUCT,core.TYPEINT,core.TYPEINT}, 
+	{Name: "Slice<T>", Pars: "arr.T,int,int", Ret: "arr.T", Code: 333, 
+		Func: SliceºArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Sort", Pars: "arr.str", Ret: "arr.str", Code: 269, 
+	{Name: "Sort", Pars: "arr.str", Ret: "arr.str", Code: 334, 
 		Func: SortºArr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Split", Pars: "str,str", Ret: "arr.str", Code: 270, 
+	{Name: "SortBy<T>", Pars: "arr.T,fn.T.T.bool", Ret: "arr.T", Code: 335, 
+		Func: SortByºArrFn, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "SortKeys<T>", Pars: "map.T", Ret: "map.T", Code: 336, 
+		Func: SortKeysºMap, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPEMAP}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "SortKeys<T>", Pars: "map[int].T", Ret: "map[int].T", Code: 337, 
+		Func: SortKeysºIntMap, Return: core.TYPEMAP, 
+		Params: []uint16{core.TYPEMAP}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Split", Pars: "str,str", Ret: "arr.str", Code: 338, 
 		Func: SplitºStrStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "SplitCmdLine", Pars: "str", Ret: "arr.str", Code: 271, 
+	{Name: "SplitCmdLine", Pars: "str", Ret: "arr.str", Code: 339, 
 		Func: SplitCmdLine, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "str", Pars: "bool", Ret: "str", Code: 272, 
+	{Name: "Status", Pars: "thread", Ret: "int", Code: 340, 
+		Func: StatusºThread, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Stop", Pars: "thread", Ret: "bool", Code: 341, 
+		Func: StopºThread, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "str", Pars: "bool", Ret: "str", Code: 342, 
 		Func: strºBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "buf", Ret: "str", Code: 273, 
+	{Name: "str", Pars: "buf", Ret: "str", Code: 343, 
 		Func: strºBuf, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "char", Ret: "str", Code: 274, 
+	{Name: "str", Pars: "char", Ret: "str", Code: 344, 
 		Func: strºChar, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "float", Ret: "str", Code: 275, 
+	{Name: "str", Pars: "float", Ret: "str", Code: 345, 
 		Func: strºFloat, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "int", Ret: "str", Code: 276, 
+	{Name: "str", Pars: "int", Ret: "str", Code: 346, 
 		Func: strºInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "obj", Ret: "str", Code: 277, 
+	{Name: "str", Pars: "obj", Ret: "str", Code: 347, 
 		Func: strºObj, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "obj,str", Ret: "str", Code: 278, 
+	{Name: "str", Pars: "obj,str", Ret: "str", Code: 348, 
 		Func: strºObjDef, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "set", Ret: "str", Code: 279, 
+	{Name: "str", Pars: "set", Ret: "str", Code: 349, 
 		Func: strºSet, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1133,11 +1413,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sub", Pars: "float,int", Ret: "float", Code: 281, 
+	{Name: "Sub", Pars: "float,int", Ret: "float", Code: 351, 
 		Func: SubºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sub", Pars: "int,float", Ret: "float", Code: 282, 
+	{Name: "Sub", Pars: "int,float", Ret: "float", Code: 352, 
 		Func: SubºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1145,121 +1425,269 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Substr", Pars: "str,int,int", Ret: "str", Code: 284, 
+	{Name: "Substr", Pars: "str,int,int", Ret: "str", Code: 354, 
 		Func: SubstrºStrIntInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "suspend", Pars: "thread", Ret: "", Code: 285, 
+	{Name: "suspend", Pars: "thread", Ret: "", Code: 355, 
 		Func: suspendºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "sysBufNil", Pars: "", Ret: "buf", Code: 286, 
+	{Name: "sysBufNil", Pars: "", Ret: "buf", Code: 356, 
 		Func: sysBufNil, Return: core.TYPEBUF, 
 		Params: nil, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "sysRun", Pars: "str,bool,buf,buf,buf,arr.str", Ret: "", Code: 287, 
+	{Name: "sysEnumInt", Pars: "str,str", Ret: "enum", Code: 357, 
+		Func: sysEnumInt, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "sysEnumStr", Pars: "enum,str", Ret: "str", Code: 358, 
+		Func: sysEnumStr, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "sysRun", Pars: "str,bool,buf,buf,buf,arr.str", Ret: "", Code: 359, 
 		Func: sysRun, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL,core.TYPEBUF,core.TYPEBUF,core.TYPEBUF,core.TYPEARR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "TempDir", Pars: "", Ret: "str", Code: 288, 
+	{Name: "TempDir", Pars: "", Ret: "str", Code: 360, 
 		Func: TempDir, Return: core.TYPESTR, 
 		Params: nil, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TempDir", Pars: "str,str", Ret: "str", Code: 289, 
+	{Name: "TempDir", Pars: "str,str", Ret: "str", Code: 361, 
 		Func: TempDirºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "terminate", Pars: "thread", Ret: "", Code: 290, 
+	{Name: "Template<T>", Pars: "str,T", Ret: "str", Code: 362, 
+		Func: Template, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPESTR,core.TYPEPARAM}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "TemplateFile<T>", Pars: "str,T", Ret: "str", Code: 363, 
+		Func: TemplateFile, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPESTR,core.TYPEPARAM}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "terminate", Pars: "thread", Ret: "", Code: 364, 
 		Func: terminateºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "time", Pars: "int", Ret: "time", Code: 291, 
+	{Name: "Tar", Pars: "str,arr.str", Ret: "", Code: 365, 
+		Func: TarºStrArr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Tar", Pars: "str,arr.str,int,str", Ret: "", Code: 366, 
+		Func: TarºStrArrIntStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR,core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Tar", Pars: "str,arr.str,int,str,fn", Ret: "", Code: 367, 
+		Func: TarºStrArrIntStrFn, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR,core.TYPEINT,core.TYPESTR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Ticker", Pars: "int,fn", Ret: "thread", Code: 368, 
+		Func: TickerºIntFn, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "time", Pars: "int", Ret: "time", Code: 369, 
 		Func: timeºInt, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Toggle", Pars: "set,int", Ret: "bool", Code: 292, 
+	{Name: "Toggle", Pars: "set,int", Ret: "bool", Code: 370, 
 		Func: ToggleºSetInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESET,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Trace", Pars: "", Ret: "arr.trace", Code: 293, 
+	{Name: "Toml", Pars: "obj", Ret: "str", Code: 371, 
+		Func: Toml, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEOBJ}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "TomlToObj", Pars: "str", Ret: "obj", Code: 372, 
+		Func: TomlToObj, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Trace", Pars: "", Ret: "arr.trace", Code: 373, 
 		Func: Trace, Return: core.TYPEARR, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Trim", Pars: "str,str", Ret: "str", Code: 294, 
+	{Name: "Trim", Pars: "str,str", Ret: "str", Code: 374, 
 		Func: TrimºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TrimLeft", Pars: "str,str", Ret: "str", Code: 295, 
+	{Name: "TrimLeft", Pars: "str,str", Ret: "str", Code: 375, 
 		Func: TrimLeftºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TrimRight", Pars: "str,str", Ret: "str", Code: 296, 
+	{Name: "TrimRight", Pars: "str,str", Ret: "str", Code: 376, 
 		Func: TrimRightºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TrimSpace", Pars: "str", Ret: "str", Code: 297, 
+	{Name: "TrimSpace", Pars: "str", Ret: "str", Code: 377, 
 		Func: TrimSpaceºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Type", Pars: "obj", Ret: "str", Code: 298, 
+	{Name: "TryLock", Pars: "str,int", Ret: "bool", Code: 378, 
+		Func: TryLockºStrInt, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "TryRLock", Pars: "str,int", Ret: "bool", Code: 379, 
+		Func: TryRLockºStrInt, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Type", Pars: "obj", Ret: "str", Code: 380, 
 		Func: Type, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "UnBase64", Pars: "str", Ret: "buf", Code: 299, 
+	{Name: "typeof", Pars: "iface", Ret: "str", Code: 381, 
+		Func: typeofºIface, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEIFACE}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "UnBase64", Pars: "str", Ret: "buf", Code: 382, 
 		Func: UnBase64ºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "UnHex", Pars: "str", Ret: "buf", Code: 300, 
+	{Name: "UnGzip", Pars: "buf", Ret: "buf", Code: 383, 
+		Func: UnGzipºBuf, Return: core.TYPEBUF, 
+		Params: []uint16{core.TYPEBUF}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnGzip", Pars: "str,str", Ret: "", Code: 384, 
+		Func: UnGzipºStrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnHex", Pars: "str", Ret: "buf", Code: 385, 
 		Func: UnHexºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Unlock", Pars: "", Ret: "", Code: 301, 
+	{Name: "Unique<T>", Pars: "arr.T", Ret: "arr.T", Code: 386, 
+		Func: UniqueºArr, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "Unlock", Pars: "", Ret: "", Code: 387, 
 		Func: Unlock, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "UnSet", Pars: "set,int", Ret: "set", Code: 302, 
+	{Name: "Unlock", Pars: "str", Ret: "", Code: 388, 
+		Func: UnlockºStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnSet", Pars: "set,int", Ret: "set", Code: 389, 
 		Func: UnSetºSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Upper", Pars: "str", Ret: "str", Code: 303, 
+	{Name: "UnTar", Pars: "str,str", Ret: "", Code: 390, 
+		Func: UnTarºStrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnTar", Pars: "str,str,int,str", Ret: "", Code: 391, 
+		Func: UnTarºStrStrIntStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnTar", Pars: "str,str,int,str,fn", Ret: "", Code: 392, 
+		Func: UnTarºStrStrIntStrFn, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEINT,core.TYPESTR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Unwrap", Pars: "error", Ret: "error", Code: 393, 
+		Func: UnwrapºError, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEERROR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "UnZip", Pars: "str,str", Ret: "", Code: 394, 
+		Func: UnZipºStrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnZip", Pars: "str,str,int,str", Ret: "", Code: 395, 
+		Func: UnZipºStrStrIntStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnZip", Pars: "str,str,int,str,fn", Ret: "", Code: 396, 
+		Func: UnZipºStrStrIntStrFn, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEINT,core.TYPESTR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Upper", Pars: "str", Ret: "str", Code: 397, 
 		Func: UpperºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "UTC", Pars: "time", Ret: "time", Code: 304, 
+	{Name: "Values<T>", Pars: "map.T", Ret: "arr.T", Code: 398, 
+		Func: ValuesºMap, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEMAP}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "Values<T>", Pars: "map[int].T", Ret: "arr.T", Code: 399, 
+		Func: ValuesºMap, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEMAP}, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "UTC", Pars: "time", Ret: "time", Code: 400, 
 		Func: UTCºTime, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "wait", Pars: "thread", Ret: "", Code: 305, 
+	{Name: "wait", Pars: "thread", Ret: "", Code: 401, 
 		Func: waitºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WaitAll", Pars: "", Ret: "", Code: 306, 
+	{Name: "WaitAll", Pars: "", Ret: "", Code: 402, 
 		Func: WaitAll, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WaitDone", Pars: "", Ret: "", Code: 307, 
+	{Name: "WaitDone", Pars: "", Ret: "", Code: 403, 
 		Func: WaitDone, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WaitGroup", Pars: "int", Ret: "", Code: 308, 
+	{Name: "WaitGroup", Pars: "int", Ret: "", Code: 404, 
 		Func: WaitGroup, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Weekday", Pars: "time", Ret: "int", Code: 309, 
+	{Name: "Weekday", Pars: "time", Ret: "int", Code: 405, 
 		Func: WeekdayºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "WriteFile", Pars: "str,buf", Ret: "", Code: 310, 
+	{Name: "Wrap", Pars: "error,str", Ret: "error", Code: 406, 
+		Func: WrapºErrorStr, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEERROR,core.TYPESTR}, 
+		Variadic: true, Runtime: true, CanError: true},
+	{Name: "WriteCSV", Pars: "str,arr.arr.str,str", Ret: "", Code: 407, 
+		Func: WriteCSVºStrArrStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "WriteFile", Pars: "str,buf", Ret: "", Code: 408, 
 		Func: WriteFileºStrBuf, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "WriteFile", Pars: "str,str", Ret: "", Code: 311, 
+	{Name: "WriteFile", Pars: "str,str", Ret: "", Code: 409, 
 		Func: WriteFileºStrStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "YearDay", Pars: "time", Ret: "int", Code: 312, 
+	{Name: "WriteIni", Pars: "str,map.map.str", Ret: "", Code: 410, 
+		Func: WriteIni, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEMAP}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Xml", Pars: "obj", Ret: "str", Code: 411, 
+		Func: Xml, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEOBJ}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "XmlToObj", Pars: "str", Ret: "obj", Code: 412, 
+		Func: XmlToObj, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "XPath", Pars: "str,str", Ret: "arr.str", Code: 413, 
+		Func: XPath, Return: core.TYPEARR, 
+		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "Yaml", Pars: "obj", Ret: "str", Code: 414, 
+		Func: Yaml, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEOBJ}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "YamlToObj", Pars: "str", Ret: "obj", Code: 415, 
+		Func: YamlToObj, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "YearDay", Pars: "time", Ret: "int", Code: 416, 
 		Func: YearDayºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Zip", Pars: "str,arr.str", Ret: "", Code: 417, 
+		Func: ZipºStrArr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Zip", Pars: "str,arr.str,int,str", Ret: "", Code: 418, 
+		Func: ZipºStrArrIntStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR,core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Zip", Pars: "str,arr.str,int,str,fn", Ret: "", Code: 419, 
+		Func: ZipºStrArrIntStrFn, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESTR,core.TYPEARR,core.TYPEINT,core.TYPESTR,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
 }
-const StdLibCount = 313
+const StdLibCount = 420
@@ -38,6 +38,14 @@ func NewStruct(rt *Runtime, sInfo *core.StructInfo) *Struct {
 	}
 }
 
+// typeofºIface returns the name of the struct type which is stored in the interface
+func typeofºIface(value interface{}) string {
+	if pstruct, ok := value.(*Struct); ok && pstruct != nil {
+		return pstruct.Type.Name
+	}
+	return ``
+}
+
 // String interface for Struct
 func (pstruct Struct) String() string {
 	name := pstruct.Type.Name
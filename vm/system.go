@@ -5,6 +5,7 @@
 package vm
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
@@ -41,10 +42,7 @@ func Command(rt *Runtime, cmdLine string) error {
 	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err = cmd.Run(); err != nil {
-		err = fmt.Errorf(err.Error())
-	}
-	return err
+	return runCommand(rt, cmd)
 }
 
 // CommandOutput executes the command line and returns the standard output
@@ -56,11 +54,38 @@ func CommandOutput(rt *Runtime, cmdLine string) (string, error) {
 	if err != nil {
 		return ``, err
 	}
-	stdout, err := cmd.CombinedOutput()
-	if err != nil {
-		err = fmt.Errorf(err.Error())
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stdout
+	err = runCommand(rt, cmd)
+	return ClearCarriage(stdout.String()), err
+}
+
+// runCommand runs the command and waits for its finish. The command is killed if
+// the deadline of timeout block has been exceeded.
+func runCommand(rt *Runtime, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf(err.Error())
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				err = fmt.Errorf(err.Error())
+			}
+			return err
+		case <-rt.timeoutTick():
+			if err := rt.timeoutError(); err != nil {
+				cmd.Process.Kill()
+				<-done
+				return err
+			}
+		}
 	}
-	return ClearCarriage(string(stdout)), err
 }
 
 // GetEnv return the value of the environment variable
@@ -88,6 +113,94 @@ func SetEnvBool(rt *Runtime, name string, value int64) (string, error) {
 	return ret, err
 }
 
+// expandEnv replaces $NAME, ${NAME} and ${NAME:-default} with the values of the
+// environment variables
+func expandEnv(value string) string {
+	return os.Expand(value, func(name string) string {
+		if def := strings.Index(name, `:-`); def >= 0 {
+			if ret := os.Getenv(name[:def]); len(ret) > 0 {
+				return ret
+			}
+			return name[def+2:]
+		}
+		return os.Getenv(name)
+	})
+}
+
+var envEscape = map[byte]string{'n': "\n", 't': "\t", '"': `"`, '\\': `\`, '$': "\x00"}
+
+// unescapeEnv replaces the escape sequences of the double-quoted value. Escaped $ is replaced
+// with zero character to skip the expansion
+func unescapeEnv(value string) string {
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			if ch, ok := envEscape[value[i+1]]; ok {
+				out.WriteString(ch)
+				i++
+				continue
+			}
+		}
+		out.WriteByte(value[i])
+	}
+	return out.String()
+}
+
+// envValue removes the trailing comment and the quotes of the value and expands the variables
+func envValue(value string) string {
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		quote := value[0]
+		end := 1
+		for ; end < len(value) && value[end] != quote; end++ {
+			if value[end] == '\\' && quote == '"' {
+				end++
+			}
+		}
+		if end < len(value) {
+			rest := strings.TrimSpace(value[end+1:])
+			switch {
+			case len(rest) > 0 && rest[0] != '#':
+				// the value is not quoted if there are characters after the closing quote
+			case quote == '\'':
+				return value[1:end]
+			default:
+				return strings.ReplaceAll(expandEnv(unescapeEnv(value[1:end])), "\x00", `$`)
+			}
+		}
+	}
+	if comment := strings.Index(value, ` #`); comment >= 0 {
+		value = strings.TrimSpace(value[:comment])
+	}
+	return expandEnv(value)
+}
+
+// LoadEnvFile assigns the environment variables from KEY=VALUE lines of the file
+func LoadEnvFile(rt *Runtime, filename string) error {
+	data, err := ReadFileºStr(rt, filename)
+	if err != nil {
+		return err
+	}
+	for i, line := range strings.Split(strings.TrimPrefix(data, bom), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, `export `)
+		eq := strings.IndexRune(line, '=')
+		var name string
+		if eq > 0 {
+			name = strings.TrimSpace(line[:eq])
+		}
+		if len(name) == 0 || strings.ContainsAny(name, " \t$") {
+			return fmt.Errorf(ErrorText(ErrParseLine), `env`, i+1, `expected KEY=VALUE`)
+		}
+		if _, err = SetEnv(rt, name, envValue(strings.TrimSpace(line[eq+1:]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func splitCmdLine(cmdLine string) (*exec.Cmd, error) {
 	var (
 		cmds      []string
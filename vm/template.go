@@ -0,0 +1,141 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/gentee/gentee/core"
+)
+
+// templateData converts gentee values to the values of text/template
+func templateData(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *core.Obj:
+		return templateData(v.Data)
+	case *core.Array:
+		ret := make([]interface{}, len(v.Data))
+		for i, item := range v.Data {
+			ret[i] = templateData(item)
+		}
+		return ret
+	case *core.Map:
+		ret := make(map[string]interface{})
+		for _, key := range v.Keys {
+			ret[key] = templateData(v.Data[key])
+		}
+		return ret
+	case *Struct:
+		ret := make(map[string]interface{})
+		for i, key := range v.Type.Keys {
+			switch v.Type.Fields[i] {
+			case core.TYPEBOOL:
+				ret[key] = v.Values[i].(int64) != 0
+			case core.TYPECHAR:
+				ret[key] = string(rune(v.Values[i].(int64)))
+			default:
+				ret[key] = templateData(v.Values[i])
+			}
+		}
+		return ret
+	case *core.Buffer:
+		return string(v.Data)
+	case int32:
+		return string(v)
+	}
+	return value
+}
+
+// isTemplateEmpty returns true if the value is the zero value of its type
+func isTemplateEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// executeTemplate executes the template. Included files are searched in the dir folder
+func executeTemplate(rt *Runtime, name, tpl, dir string, data interface{}, deep int) (string,
+	error) {
+	var includeErr error
+	if deep > CtxDeep {
+		return ``, fmt.Errorf(ErrCtxDeep)
+	}
+	funcs := template.FuncMap{
+		`default`: func(def, value interface{}) interface{} {
+			if isTemplateEmpty(value) {
+				return def
+			}
+			return value
+		},
+		`include`: func(filename string, data interface{}) (string, error) {
+			if !filepath.IsAbs(filename) {
+				filename = filepath.Join(dir, filename)
+			}
+			input, err := ReadFileºStr(rt, filename)
+			if err == nil {
+				input, err = executeTemplate(rt, filename, input, filepath.Dir(filename), data,
+					deep+1)
+			}
+			if err != nil && includeErr == nil {
+				includeErr = err
+			}
+			return input, err
+		},
+		`join`: func(items []interface{}, sep string) string {
+			list := make([]string, len(items))
+			for i, item := range items {
+				list[i] = fmt.Sprint(item)
+			}
+			return strings.Join(list, sep)
+		},
+		`json`: func(value interface{}) (string, error) {
+			out, err := json.Marshal(value)
+			return string(out), err
+		},
+		`lower`:   strings.ToLower,
+		`replace`: strings.ReplaceAll,
+		`trim`:    strings.TrimSpace,
+		`upper`:   strings.ToUpper,
+	}
+	t, err := template.New(name).Funcs(funcs).Option(`missingkey=zero`).Parse(tpl)
+	if err != nil {
+		return ``, err
+	}
+	var out strings.Builder
+	if err = t.Execute(&out, data); err != nil {
+		if includeErr != nil {
+			// the error of the included file is returned without the wrapping
+			return ``, includeErr
+		}
+		return ``, err
+	}
+	return out.String(), nil
+}
+
+// Template executes text/template with the data which can be obj, map, arr or struct
+func Template(rt *Runtime, tpl string, data interface{}) (string, error) {
+	return executeTemplate(rt, `template`, tpl, ``, templateData(data), 0)
+}
+
+// TemplateFile executes the template file with the data which can be obj, map, arr or struct
+func TemplateFile(rt *Runtime, filename string, data interface{}) (string, error) {
+	tpl, err := ReadFileºStr(rt, filename)
+	if err != nil {
+		return ``, err
+	}
+	return executeTemplate(rt, filepath.Base(filename), tpl, filepath.Dir(filename),
+		templateData(data), 0)
+}
@@ -6,6 +6,9 @@ package vm
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
+	"time"
 
 	"github.com/gentee/gentee/core"
 )
@@ -42,6 +45,13 @@ type Thread struct {
 	Sleep  int64
 	Chan   chan int
 	Notify []int64 // who waits the end
+	Done   chan struct{}
+	Kept   bool        // the error is kept in the thread and is not sent to ChError
+	Result interface{} // the result of the thread function
+	Err    error       // the error of the thread function
+	Caught bool        // the error has been got by ErrOf or Result
+	Timer  bool        // the thread of After or Ticker is stopped when the script finishes
+	Start  time.Time
 }
 
 /*
@@ -114,15 +124,18 @@ func (vm *VM) newThread(status byte) *Runtime {
 		Thread: Thread{
 			Status: status,
 			Chan:   make(chan int, 8),
+			Done:   make(chan struct{}),
+			Start:  time.Now(),
 		},
 	}
 	vm.ThreadMutex.Lock()
-	defer vm.ThreadMutex.Unlock()
 	vm.Runtimes = append(vm.Runtimes, rt)
 	rt.ThreadID = int64(len(vm.Runtimes) - 1)
 	if status == ThQueue {
 		vm.Count++
 	}
+	vm.ThreadMutex.Unlock()
+	vm.event(rt, nil)
 	return rt
 }
 
@@ -136,12 +149,24 @@ func (vm *VM) closeAll() {
 	vm.ThreadMutex.Unlock()
 }
 
+// stopTimers closes the active timers and tickers
+func (vm *VM) stopTimers() {
+	vm.ThreadMutex.Lock()
+	for _, thread := range vm.Runtimes {
+		if thread.Thread.Timer && thread.Thread.Status < ThFinished {
+			thread.Thread.Chan <- ThCmdClose
+		}
+	}
+	vm.ThreadMutex.Unlock()
+}
+
 // GoThread executes a new thread
-func (rt *Runtime) GoThread(offset int64, pars []int32, top *Call) int64 {
+func (rt *Runtime) GoThread(offset int64, pars []int32, top *Call, kept bool) int64 {
 	thread := rt.Owner.newThread(ThQueue)
 	if thread == nil {
 		return -1
 	}
+	thread.Thread.Kept = kept
 	optional := make([]OptValue, len(pars))
 	for i := len(pars) - 1; i >= 0; i-- {
 		var value interface{}
@@ -169,27 +194,205 @@ func (rt *Runtime) GoThread(offset int64, pars []int32, top *Call) int64 {
 
 	go func() {
 		thread.Thread.Status = ThWork
+		thread.finish(thread.Run(offset))
+	}()
+	return thread.ThreadID
+}
 
-		_, err := thread.Run(offset)
-		rt.Owner.ThreadMutex.Lock()
-		if err != nil {
-			if thread.Thread.Status != ThClosed {
-				thread.Thread.Status = ThError
-				rt.Owner.ChError <- err
+// finish completes the thread and notifies the waiting threads
+func (thread *Runtime) finish(result interface{}, err error) {
+	vm := thread.Owner
+	releaseLocks(thread)
+	vm.ThreadMutex.Lock()
+	if err != nil {
+		thread.Thread.Err = err
+		if thread.Thread.Status != ThClosed {
+			thread.Thread.Status = ThError
+			if rterr, ok := err.(*RuntimeError); !thread.Thread.Kept ||
+				(ok && rterr.Message == ErrorText(ErrExit)) {
+				thread.Thread.Caught = true
+				vm.ChError <- err
+			}
+		}
+	} else {
+		thread.Thread.Status = ThFinished
+		thread.Thread.Result = result
+	}
+	close(thread.Thread.Chan)
+	close(thread.Thread.Done)
+	for _, nfyid := range thread.Thread.Notify {
+		if vm.Runtimes[nfyid].Thread.Status == ThWait {
+			vm.Runtimes[nfyid].Thread.Chan <- ThCmdContinue
+		}
+	}
+	vm.ThreadMutex.Unlock()
+	vm.event(thread, err)
+	vm.ChCount <- 1
+}
+
+// doneThread waits for the finish of the thread and returns it
+func doneThread(rt *Runtime, threadID int64) (*Runtime, error) {
+	rt.Owner.ThreadMutex.RLock()
+	if threadID <= 0 || int64(len(rt.Owner.Runtimes)) <= threadID || threadID == rt.ThreadID {
+		rt.Owner.ThreadMutex.RUnlock()
+		return nil, fmt.Errorf(ErrorText(ErrThreadIndex))
+	}
+	thread := rt.Owner.Runtimes[threadID]
+	rt.Owner.ThreadMutex.RUnlock()
+	for {
+		if err := rt.timeoutError(); err != nil {
+			return nil, err
+		}
+		if rt.ThreadID == 0 {
+			select {
+			case <-thread.Thread.Done:
+				return thread, nil
+			case err := <-rt.Owner.ChError:
+				return nil, err
+			case <-rt.timeoutTick():
 			}
-		} else {
-			thread.Thread.Status = ThFinished
+			continue
 		}
-		close(thread.Thread.Chan)
-		for _, nfyid := range thread.Thread.Notify {
-			if rt.Owner.Runtimes[nfyid].Thread.Status == ThWait {
-				rt.Owner.Runtimes[nfyid].Thread.Chan <- ThCmdContinue
+		select {
+		case <-thread.Thread.Done:
+			return thread, nil
+		case x := <-rt.Thread.Chan:
+			if x == ThCmdClose {
+				rt.setStatus(ThClosed)
+				return nil, fmt.Errorf(ErrorText(ErrThreadClosed))
 			}
+		case <-rt.timeoutTick():
 		}
-		rt.Owner.ThreadMutex.Unlock()
-		rt.Owner.ChCount <- 1
-	}()
-	return thread.ThreadID
+	}
+}
+
+// ErrOfºThread waits for the finish of the thread and returns its error
+func ErrOfºThread(rt *Runtime, threadID int64) (*RuntimeError, error) {
+	thread, err := doneThread(rt, threadID)
+	if err != nil {
+		return nil, err
+	}
+	rt.Owner.ThreadMutex.Lock()
+	defer rt.Owner.ThreadMutex.Unlock()
+	thread.Thread.Caught = true
+	switch v := thread.Thread.Err.(type) {
+	case nil:
+		return &RuntimeError{}, nil
+	case *RuntimeError:
+		return v, nil
+	default:
+		return &RuntimeError{ID: ErrEmbedded, Message: v.Error()}, nil
+	}
+}
+
+// threadResult converts the result of the thread to obj. The struct value is converted to
+// the map of its fields and buf is converted to the string
+func threadResult(value interface{}) (*core.Obj, error) {
+	ret := core.NewObj()
+	switch v := value.(type) {
+	case nil:
+		return ret, nil
+	case rune:
+		value = int64(v)
+	case *core.Buffer:
+		value = string(v.Data)
+	case *Struct:
+		data := core.NewMap()
+		for i, key := range v.Type.Keys {
+			item := v.Values[i]
+			if v.Type.Fields[i] == core.TYPEBOOL {
+				item = item.(int64) != 0
+			}
+			iobj, err := threadResult(item)
+			if err != nil {
+				return nil, err
+			}
+			data.Keys = append(data.Keys, key)
+			data.Data[key] = iobj
+		}
+		ret.Data = data
+		return ret, nil
+	case *core.Array:
+		data := core.NewArray()
+		for _, item := range v.Data {
+			iobj, err := threadResult(item)
+			if err != nil {
+				return nil, err
+			}
+			data.Data = append(data.Data, iobj)
+		}
+		ret.Data = data
+		return ret, nil
+	case *core.Map:
+		data := core.NewMap()
+		for _, key := range v.Keys {
+			iobj, err := threadResult(v.Data[key])
+			if err != nil {
+				return nil, err
+			}
+			data.Keys = append(data.Keys, key)
+			data.Data[key] = iobj
+		}
+		ret.Data = data
+		return ret, nil
+	}
+	return objType(value)
+}
+
+// threadValue waits for the finish of the thread and returns its result
+func threadValue(rt *Runtime, threadID int64) (interface{}, error) {
+	thread, err := doneThread(rt, threadID)
+	if err != nil {
+		return nil, err
+	}
+	rt.Owner.ThreadMutex.Lock()
+	defer rt.Owner.ThreadMutex.Unlock()
+	thread.Thread.Caught = true
+	return thread.Thread.Result, thread.Thread.Err
+}
+
+// ResultºThread waits for the finish of the thread and returns its result. The type of
+// thread doesn't keep the type of the result so the result is obj. Use int(obj),
+// str(obj) and other conversions to get the value. The struct value is returned as the map
+// of its fields and buf is returned as the string.
+func ResultºThread(rt *Runtime, threadID int64) (*core.Obj, error) {
+	result, err := threadValue(rt, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return threadResult(result)
+}
+
+// ResultºTypedThread waits for the finish of thread.T and returns its result as T
+func ResultºTypedThread(rt *Runtime, threadID int64) (interface{}, error) {
+	result, err := threadValue(rt, threadID)
+	if err != nil {
+		return nil, err
+	}
+	switch v := result.(type) {
+	case rune:
+		return int64(v), nil
+	case bool:
+		if v {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case int64, float64, string:
+		return v, nil
+	}
+	var ret interface{}
+	CopyVar(rt, &ret, result)
+	return ret, nil
+}
+
+// StatusºThread returns the status of the thread
+func StatusºThread(rt *Runtime, threadID int64) (int64, error) {
+	rt.Owner.ThreadMutex.RLock()
+	defer rt.Owner.ThreadMutex.RUnlock()
+	if threadID < 0 || int64(len(rt.Owner.Runtimes)) <= threadID {
+		return 0, fmt.Errorf(ErrorText(ErrThreadIndex))
+	}
+	return int64(rt.Owner.Runtimes[threadID].Thread.Status), nil
 }
 
 // Lock locks vm mutex
@@ -291,3 +494,230 @@ func WaitGroup(rt *Runtime, count int64) error {
 	rt.Owner.WaitCount = count
 	return nil
 }
+
+// runFn runs the function. It is assigned in init to avoid the initialization cycle of EmbedFuncs
+var runFn func(rt *Runtime, offset int64) (interface{}, error)
+
+func init() {
+	runFn = (*Runtime).Run
+}
+
+// callFn calls fn function in the runtime of the worker
+func (worker *Runtime) callFn(fn *Fn, pars ...interface{}) (interface{}, error) {
+	if fn == nil || fn.Func == 0 {
+		return nil, fmt.Errorf(ErrorText(ErrFnEmpty))
+	}
+	optional := make([]OptValue, len(pars), len(pars)+len(fn.Env))
+	for i, par := range pars {
+		optional[i] = OptValue{Var: int32(i), Value: par}
+	}
+	optional = append(optional, fn.Env...)
+	worker.Calls = worker.Calls[:0]
+	worker.Optional = &optional
+	return runFn(worker, int64(worker.Owner.Exec.Funcs[fn.Func]))
+}
+
+// closeWorker marks the worker thread as finished
+func (worker *Runtime) closeWorker() {
+	releaseLocks(worker)
+	worker.Owner.ThreadMutex.Lock()
+	if worker.Thread.Status < ThFinished {
+		worker.Thread.Status = ThFinished
+	}
+	close(worker.Thread.Chan)
+	close(worker.Thread.Done)
+	worker.Owner.ThreadMutex.Unlock()
+	worker.Owner.event(worker, nil)
+}
+
+// ParallelForºArrIntFn calls fn for each item of the array in workers threads
+func ParallelForºArrIntFn(rt *Runtime, arr *core.Array, workers int64, fn *Fn) (*core.Array, error) {
+	return ParallelForºArrIntFnBool(rt, arr, workers, fn, 1)
+}
+
+// ParallelForºArrIntFnBool calls fn for each item of the array in workers threads.
+// If stop is true then the remaining items are skipped after the first error
+func ParallelForºArrIntFnBool(rt *Runtime, arr *core.Array, workers int64, fn *Fn,
+	stopErr int64) (*core.Array, error) {
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		errInd   = -1
+		errRet   error
+		stop     = stopErr != 0
+	)
+	if fn == nil || fn.Func == 0 {
+		return nil, fmt.Errorf(ErrorText(ErrFnEmpty))
+	}
+	if workers <= 0 {
+		workers = int64(runtime.NumCPU())
+	}
+	if workers > int64(len(arr.Data)) {
+		workers = int64(len(arr.Data))
+	}
+	results := make([]interface{}, len(arr.Data))
+	items := make(chan int, len(arr.Data))
+	for i := range arr.Data {
+		items <- i
+	}
+	close(items)
+	pool := make([]*Runtime, 0, workers)
+	for i := int64(0); i < workers; i++ {
+		worker := rt.Owner.newThread(ThWork)
+		if worker == nil {
+			break
+		}
+		pool = append(pool, worker)
+	}
+	for _, worker := range pool {
+		wg.Add(1)
+		go func(worker *Runtime) {
+			defer wg.Done()
+			for ind := range items {
+				errMutex.Lock()
+				skip := stop && errRet != nil
+				errMutex.Unlock()
+				if skip {
+					continue
+				}
+				result, err := worker.callFn(fn, arr.Data[ind])
+				if err != nil {
+					errMutex.Lock()
+					if errRet == nil || (!stop && ind < errInd) {
+						errInd, errRet = ind, err
+						if stop {
+							for _, item := range pool {
+								if item != worker {
+									rt.Owner.ThreadMutex.RLock()
+									if item.Thread.Status == ThWork {
+										item.Thread.Chan <- ThCmdClose
+									}
+									rt.Owner.ThreadMutex.RUnlock()
+								}
+							}
+						}
+					}
+					errMutex.Unlock()
+					if worker.Thread.Status == ThClosed {
+						return
+					}
+					continue
+				}
+				results[ind] = result
+			}
+		}(worker)
+	}
+	wg.Wait()
+	for _, worker := range pool {
+		worker.closeWorker()
+	}
+	if errRet != nil {
+		return nil, errRet
+	}
+	if len(pool) == 0 && len(arr.Data) > 0 {
+		return nil, fmt.Errorf(ErrorText(ErrThreadClosed))
+	}
+	ret := core.NewArray()
+	ret.Data = make([]interface{}, len(results))
+	for i, result := range results {
+		obj, err := threadResult(result)
+		if err != nil {
+			return nil, err
+		}
+		ret.Data[i] = obj
+	}
+	return ret, nil
+}
+
+// sleepTimer pauses the timer thread. The suspended thread or script doesn't decrease the duration.
+func (thread *Runtime) sleepTimer(d int64) error {
+	thread.Thread.Sleep = d
+	for thread.Thread.Sleep > 0 || thread.Thread.Status == ThPaused {
+		step := SleepStep
+		if thread.Thread.Sleep > 0 && thread.Thread.Sleep < step {
+			step = thread.Thread.Sleep
+		}
+		select {
+		case x := <-thread.Thread.Chan:
+			switch x {
+			case ThCmdResume, ThCmdContinue:
+				thread.setStatus(ThWork)
+			case ThCmdClose:
+				thread.setStatus(ThClosed)
+				return fmt.Errorf(ErrorText(ErrThreadClosed))
+			}
+		case <-time.After(time.Duration(step) * time.Millisecond):
+			if !thread.Owner.Stopped && thread.Thread.Status != ThPaused {
+				thread.Thread.Sleep -= step
+			}
+		}
+	}
+	return nil
+}
+
+// goTimer starts the thread which calls fn after d milliseconds. If ticker is true then
+// fn is called every d milliseconds until the thread is stopped.
+func goTimer(rt *Runtime, d int64, fn *Fn, ticker bool) (int64, error) {
+	if d < 0 || (ticker && d == 0) {
+		return 0, fmt.Errorf(ErrorText(ErrInvalidParam))
+	}
+	if fn != nil && fn.Func == 0 {
+		return 0, fmt.Errorf(ErrorText(ErrFnEmpty))
+	}
+	thread := rt.Owner.newThread(ThQueue)
+	if thread == nil {
+		return 0, fmt.Errorf(ErrorText(ErrThreadClosed))
+	}
+	rt.Owner.ThreadMutex.Lock()
+	thread.Thread.Timer = true
+	rt.Owner.ThreadMutex.Unlock()
+	go func() {
+		var (
+			result interface{}
+			err    error
+		)
+		thread.Thread.Status = ThWork
+		for {
+			if err = thread.sleepTimer(d); err != nil || fn == nil {
+				break
+			}
+			if result, err = thread.callFn(fn); err != nil || !ticker {
+				break
+			}
+		}
+		thread.finish(result, err)
+	}()
+	return thread.ThreadID, nil
+}
+
+// AfterºInt returns the thread which finishes after the specified duration in milliseconds
+func AfterºInt(rt *Runtime, d int64) (int64, error) {
+	return goTimer(rt, d, nil, false)
+}
+
+// AfterºIntFn calls fn in a new thread after the specified duration in milliseconds
+func AfterºIntFn(rt *Runtime, d int64, fn *Fn) (int64, error) {
+	if fn == nil {
+		return 0, fmt.Errorf(ErrorText(ErrFnEmpty))
+	}
+	return goTimer(rt, d, fn, false)
+}
+
+// TickerºIntFn calls fn in a new thread every d milliseconds until the thread is stopped
+func TickerºIntFn(rt *Runtime, d int64, fn *Fn) (int64, error) {
+	if fn == nil {
+		return 0, fmt.Errorf(ErrorText(ErrFnEmpty))
+	}
+	return goTimer(rt, d, fn, true)
+}
+
+// StopºThread stops the timer or the thread. It returns false if the thread has already finished.
+func StopºThread(rt *Runtime, threadID int64) (stopped int64, err error) {
+	err = changeStatus(rt, threadID, func(vm *VM) {
+		if vm.Runtimes[threadID].Thread.Status < ThFinished {
+			vm.Runtimes[threadID].Thread.Chan <- ThCmdClose
+			stopped = 1
+		}
+	})
+	return
+}
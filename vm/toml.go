@@ -0,0 +1,714 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gentee/gentee/core"
+)
+
+var (
+	tomlDate = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?` +
+		`([Zz]|[-+]\d{2}:\d{2})?)?|\d{2}:\d{2}:\d{2}(\.\d+)?)$`)
+	tomlFloat = regexp.MustCompile(`^[-+]?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+	tomlKey   = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+)
+
+// tomlParser parses TOML documents
+type tomlParser struct {
+	input   string
+	pos     int
+	line    int
+	root    *core.Map
+	current *core.Map
+	defined map[*core.Map]bool   // tables defined with [table] headers
+	inline  map[*core.Map]bool   // inline tables which can't be extended
+	tables  map[*core.Array]bool // arrays defined with [[array]] headers
+}
+
+func tomlError(line int, format string, args ...interface{}) error {
+	return fmt.Errorf(ErrorText(ErrParseLine), `toml`, line, fmt.Sprintf(format, args...))
+}
+
+func (p *tomlParser) error(format string, args ...interface{}) error {
+	return tomlError(p.line, format, args...)
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipLines skips white spaces, new lines and comments
+func (p *tomlParser) skipLines() {
+	for !p.eof() {
+		switch p.input[p.pos] {
+		case '\n':
+			p.line++
+		case ' ', '\t', '\r':
+		case '#':
+			p.skipComment()
+			continue
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	if p.peek() == '#' {
+		for !p.eof() && p.input[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+}
+
+// endLine checks that there is nothing but a comment till the end of the line
+func (p *tomlParser) endLine() error {
+	p.skipSpace()
+	p.skipComment()
+	if p.peek() == '\r' {
+		p.pos++
+	}
+	if !p.eof() && p.input[p.pos] != '\n' {
+		return p.error(`expected the end of the line, found '%c'`, p.input[p.pos])
+	}
+	return nil
+}
+
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		var key string
+		switch p.peek() {
+		case '"', '\'':
+			str, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = str
+		default:
+			start := p.pos
+			for !p.eof() && (tomlKey.MatchString(p.input[p.pos : p.pos+1])) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.error(`invalid key`)
+			}
+			key = p.input[start:p.pos]
+		}
+		keys = append(keys, key)
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *tomlParser) parseString() (string, error) {
+	switch {
+	case strings.HasPrefix(p.input[p.pos:], `"""`):
+		return p.parseBasic(true)
+	case strings.HasPrefix(p.input[p.pos:], `'''`):
+		return p.parseLiteral(true)
+	case p.peek() == '"':
+		return p.parseBasic(false)
+	}
+	return p.parseLiteral(false)
+}
+
+// closing returns the length of the closing quotes of the multiline string
+func (p *tomlParser) closing(quote string) int {
+	if !strings.HasPrefix(p.input[p.pos:], quote) {
+		return 0
+	}
+	// up to two additional quotes are the part of the string
+	size := 3
+	for size < 5 && p.pos+size < len(p.input) && p.input[p.pos+size] == quote[0] {
+		size++
+	}
+	return size
+}
+
+func (p *tomlParser) skipFirstNewLine(multi bool) {
+	if multi {
+		if strings.HasPrefix(p.input[p.pos:], "\r\n") {
+			p.pos++
+		}
+		if p.peek() == '\n' {
+			p.pos++
+			p.line++
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteral(multi bool) (string, error) {
+	var out strings.Builder
+	quote := `'`
+	if multi {
+		quote = `'''`
+	}
+	p.pos += len(quote)
+	p.skipFirstNewLine(multi)
+	for !p.eof() {
+		ch := p.input[p.pos]
+		if multi {
+			if size := p.closing(quote); size > 0 {
+				out.WriteString(p.input[p.pos+3 : p.pos+size])
+				p.pos += size
+				return out.String(), nil
+			}
+		} else if ch == '\'' {
+			p.pos++
+			return out.String(), nil
+		}
+		if ch == '\n' {
+			if !multi {
+				break
+			}
+			p.line++
+		}
+		out.WriteByte(ch)
+		p.pos++
+	}
+	return ``, p.error(`unterminated string`)
+}
+
+func (p *tomlParser) parseBasic(multi bool) (string, error) {
+	var out strings.Builder
+	quote := `"`
+	if multi {
+		quote = `"""`
+	}
+	p.pos += len(quote)
+	p.skipFirstNewLine(multi)
+	for !p.eof() {
+		ch := p.input[p.pos]
+		if multi {
+			if size := p.closing(quote); size > 0 {
+				out.WriteString(p.input[p.pos+3 : p.pos+size])
+				p.pos += size
+				return out.String(), nil
+			}
+		} else if ch == '"' {
+			p.pos++
+			return out.String(), nil
+		}
+		p.pos++
+		switch ch {
+		case '\n':
+			if !multi {
+				return ``, p.error(`unterminated string`)
+			}
+			p.line++
+		case '\\':
+			if p.eof() {
+				continue
+			}
+			esc := p.input[p.pos]
+			p.pos++
+			switch esc {
+			case 'b':
+				out.WriteByte('\b')
+			case 't':
+				out.WriteByte('\t')
+			case 'n':
+				out.WriteByte('\n')
+			case 'f':
+				out.WriteByte('\f')
+			case 'r':
+				out.WriteByte('\r')
+			case '"', '\\':
+				out.WriteByte(esc)
+			case 'u', 'U':
+				size := 4
+				if esc == 'U' {
+					size = 8
+				}
+				if p.pos+size > len(p.input) {
+					return ``, p.error(`invalid escape sequence \%c`, esc)
+				}
+				code, err := strconv.ParseUint(p.input[p.pos:p.pos+size], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(code)) {
+					return ``, p.error(`invalid escape sequence \%c%s`, esc,
+						p.input[p.pos:p.pos+size])
+				}
+				out.WriteRune(rune(code))
+				p.pos += size
+			case ' ', '\t', '\r', '\n':
+				if !multi {
+					return ``, p.error(`invalid escape sequence \%c`, esc)
+				}
+				// the line ending backslash trims all white spaces till the next text
+				p.pos--
+				for !p.eof() && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+					if p.input[p.pos] == '\n' {
+						p.line++
+					}
+					p.pos++
+				}
+			default:
+				return ``, p.error(`invalid escape sequence \%c`, esc)
+			}
+			continue
+		}
+		out.WriteByte(ch)
+	}
+	return ``, p.error(`unterminated string`)
+}
+
+func (p *tomlParser) parseValue() (*core.Obj, error) {
+	ret := core.NewObj()
+	switch p.peek() {
+	case 0, '\n', '\r', '#':
+		return nil, p.error(`missing value`)
+	case '"', '\'':
+		str, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		ret.Data = str
+		return ret, nil
+	case '[':
+		arr, err := p.parseArray()
+		if err != nil {
+			return nil, err
+		}
+		ret.Data = arr
+		return ret, nil
+	case '{':
+		data, err := p.parseInline()
+		if err != nil {
+			return nil, err
+		}
+		ret.Data = data
+		return ret, nil
+	}
+	start := p.pos
+	for !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.input[p.pos]) < 0 {
+		p.pos++
+	}
+	// the date and the time can be separated by the space
+	if p.pos-start == 10 && p.peek() == ' ' && p.pos+1 < len(p.input) &&
+		p.input[p.pos+1] >= '0' && p.input[p.pos+1] <= '9' {
+		for p.pos++; !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.input[p.pos]) < 0; p.pos++ {
+		}
+	}
+	value := p.input[start:p.pos]
+	switch value {
+	case `true`:
+		ret.Data = true
+	case `false`:
+		ret.Data = false
+	case `inf`, `+inf`:
+		ret.Data = math.Inf(1)
+	case `-inf`:
+		ret.Data = math.Inf(-1)
+	case `nan`, `+nan`, `-nan`:
+		ret.Data = math.NaN()
+	default:
+		if tomlDate.MatchString(value) {
+			ret.Data = value
+			break
+		}
+		number := strings.ReplaceAll(value, `_`, ``)
+		if strings.HasPrefix(value, `_`) || strings.HasSuffix(value, `_`) ||
+			strings.Contains(value, `__`) {
+			return nil, p.error(`invalid value %s`, value)
+		}
+		if strings.HasPrefix(number, `0x`) || strings.HasPrefix(number, `0o`) ||
+			strings.HasPrefix(number, `0b`) {
+			i, err := strconv.ParseInt(number, 0, 64)
+			if err != nil {
+				return nil, p.error(`invalid value %s`, value)
+			}
+			ret.Data = i
+		} else if tomlFloat.MatchString(number) {
+			if strings.ContainsAny(number, `.eE`) {
+				f, err := strconv.ParseFloat(number, 64)
+				if err != nil {
+					return nil, p.error(`invalid value %s`, value)
+				}
+				ret.Data = f
+			} else {
+				i, err := strconv.ParseInt(number, 10, 64)
+				if err != nil {
+					return nil, p.error(`invalid value %s`, value)
+				}
+				ret.Data = i
+			}
+		} else {
+			return nil, p.error(`invalid value %s`, value)
+		}
+	}
+	return ret, nil
+}
+
+func (p *tomlParser) parseArray() (*core.Array, error) {
+	ret := core.NewArray()
+	for p.pos++; ; {
+		p.skipLines()
+		if p.eof() {
+			return nil, p.error(`unterminated array`)
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return ret, nil
+		}
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		ret.Data = append(ret.Data, item)
+		p.skipLines()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.error(`expected ',' or ']' in array`)
+		}
+	}
+}
+
+func (p *tomlParser) parseInline() (*core.Map, error) {
+	ret := core.NewMap()
+	p.inline[ret] = true
+	p.pos++
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return ret, nil
+	}
+	for {
+		if err := p.parseKeyValue(ret); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return ret, nil
+		default:
+			return nil, p.error(`expected ',' or '}' in inline table`)
+		}
+	}
+}
+
+// subTable returns the table with the key name creating it if it is required
+func (p *tomlParser) subTable(table *core.Map, name string, header bool) (*core.Map, error) {
+	item, ok := table.Data[name]
+	if !ok {
+		sub := core.NewMap()
+		obj := core.NewObj()
+		obj.Data = sub
+		table.Keys = append(table.Keys, name)
+		table.Data[name] = obj
+		return sub, nil
+	}
+	switch v := item.(*core.Obj).Data.(type) {
+	case *core.Map:
+		if !p.inline[v] {
+			return v, nil
+		}
+	case *core.Array:
+		if header && p.tables[v] {
+			return v.Data[len(v.Data)-1].(*core.Obj).Data.(*core.Map), nil
+		}
+	}
+	return nil, p.error(`key %s has already been defined`, name)
+}
+
+func (p *tomlParser) parseKeyValue(table *core.Map) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.peek() != '=' {
+		return p.error(`expected '=' after the key`)
+	}
+	p.pos++
+	p.skipSpace()
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.subTable(table, key, false); err != nil {
+			return err
+		}
+	}
+	name := keys[len(keys)-1]
+	if _, ok := table.Data[name]; ok {
+		return p.error(`key %s has already been defined`, name)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+	table.Keys = append(table.Keys, name)
+	table.Data[name] = value
+	return nil
+}
+
+func (p *tomlParser) parseHeader() error {
+	p.pos++
+	isArray := p.peek() == '['
+	if isArray {
+		p.pos++
+	}
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.peek() != ']' || isArray && !strings.HasPrefix(p.input[p.pos:], `]]`) {
+		return p.error(`invalid table header`)
+	}
+	p.pos++
+	if isArray {
+		p.pos++
+	}
+	if err = p.endLine(); err != nil {
+		return err
+	}
+	table := p.root
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.subTable(table, key, true); err != nil {
+			return err
+		}
+	}
+	name := keys[len(keys)-1]
+	fullName := strings.Join(keys, `.`)
+	if isArray {
+		var arr *core.Array
+		if item, ok := table.Data[name]; ok {
+			arr, _ = item.(*core.Obj).Data.(*core.Array)
+			if arr == nil || !p.tables[arr] {
+				return p.error(`key %s has already been defined`, fullName)
+			}
+		} else {
+			arr = core.NewArray()
+			p.tables[arr] = true
+			obj := core.NewObj()
+			obj.Data = arr
+			table.Keys = append(table.Keys, name)
+			table.Data[name] = obj
+		}
+		p.current = core.NewMap()
+		obj := core.NewObj()
+		obj.Data = p.current
+		arr.Data = append(arr.Data, obj)
+		return nil
+	}
+	if item, ok := table.Data[name]; ok {
+		sub, _ := item.(*core.Obj).Data.(*core.Map)
+		if sub == nil || p.defined[sub] || p.inline[sub] {
+			return p.error(`table %s has already been defined`, fullName)
+		}
+		p.current = sub
+	} else if p.current, err = p.subTable(table, name, true); err != nil {
+		return err
+	}
+	p.defined[p.current] = true
+	return nil
+}
+
+// TomlToObj converts TOML to object
+func TomlToObj(input string) (*core.Obj, error) {
+	p := &tomlParser{
+		input:   input,
+		line:    1,
+		root:    core.NewMap(),
+		defined: make(map[*core.Map]bool),
+		inline:  make(map[*core.Map]bool),
+		tables:  make(map[*core.Array]bool),
+	}
+	p.current = p.root
+	for p.skipLines(); !p.eof(); p.skipLines() {
+		var err error
+		if p.peek() == '[' {
+			err = p.parseHeader()
+		} else if err = p.parseKeyValue(p.current); err == nil {
+			err = p.endLine()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	ret := core.NewObj()
+	ret.Data = p.root
+	return ret, nil
+}
+
+// tomlString returns the string as TOML basic string
+func tomlString(value string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+	for _, ch := range value {
+		switch ch {
+		case '"', '\\':
+			out.WriteString(`\` + string(ch))
+		case '\b':
+			out.WriteString(`\b`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\f':
+			out.WriteString(`\f`)
+		case '\r':
+			out.WriteString(`\r`)
+		default:
+			if ch < 0x20 || ch == 0x7f {
+				out.WriteString(fmt.Sprintf(`\u%04X`, ch))
+			} else {
+				out.WriteRune(ch)
+			}
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
+func tomlKeyName(key string) string {
+	if tomlKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlValue returns the value as the scalar, the inline array or the inline table
+func tomlValue(value interface{}, path string) (string, error) {
+	switch v := value.(type) {
+	case *core.Obj:
+		return tomlValue(v.Data, path)
+	case nil:
+		return ``, fmt.Errorf(ErrorText(ErrTomlNull), path)
+	case string:
+		return tomlString(v), nil
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return `inf`, nil
+		case math.IsInf(v, -1):
+			return `-inf`, nil
+		case math.IsNaN(v):
+			return `nan`, nil
+		}
+		ret := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(ret, `.e`) {
+			ret += `.0`
+		}
+		return ret, nil
+	case *core.Array:
+		items := make([]string, len(v.Data))
+		for i, item := range v.Data {
+			str, err := tomlValue(item, fmt.Sprintf(`%s[%d]`, path, i))
+			if err != nil {
+				return ``, err
+			}
+			items[i] = str
+		}
+		return `[` + strings.Join(items, `, `) + `]`, nil
+	case *core.Map:
+		items := make([]string, len(v.Keys))
+		for i, key := range v.Keys {
+			str, err := tomlValue(v.Data[key], path+`.`+key)
+			if err != nil {
+				return ``, err
+			}
+			items[i] = tomlKeyName(key) + ` = ` + str
+		}
+		if len(items) == 0 {
+			return `{}`, nil
+		}
+		return `{ ` + strings.Join(items, `, `) + ` }`, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// tomlTables returns the array if all its items are tables
+func tomlTables(value interface{}) *core.Array {
+	arr, ok := value.(*core.Array)
+	if !ok || len(arr.Data) == 0 {
+		return nil
+	}
+	for _, item := range arr.Data {
+		if _, ok := item.(*core.Obj).Data.(*core.Map); !ok {
+			return nil
+		}
+	}
+	return arr
+}
+
+func writeToml(out *strings.Builder, table *core.Map, path string) error {
+	for _, key := range table.Keys {
+		value := table.Data[key].(*core.Obj).Data
+		if _, ok := value.(*core.Map); ok || tomlTables(value) != nil {
+			continue
+		}
+		str, err := tomlValue(value, strings.TrimPrefix(path+`.`+key, `.`))
+		if err != nil {
+			return err
+		}
+		out.WriteString(tomlKeyName(key) + ` = ` + str + "\n")
+	}
+	for _, key := range table.Keys {
+		value := table.Data[key].(*core.Obj).Data
+		name := strings.TrimPrefix(path+`.`+tomlKeyName(key), `.`)
+		if sub, ok := value.(*core.Map); ok {
+			if out.Len() > 0 {
+				out.WriteString("\n")
+			}
+			out.WriteString(`[` + name + "]\n")
+			if err := writeToml(out, sub, name); err != nil {
+				return err
+			}
+		} else if arr := tomlTables(value); arr != nil {
+			for _, item := range arr.Data {
+				if out.Len() > 0 {
+					out.WriteString("\n")
+				}
+				out.WriteString(`[[` + name + "]]\n")
+				if err := writeToml(out, item.(*core.Obj).Data.(*core.Map), name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Toml converts object to TOML
+func Toml(obj *core.Obj) (string, error) {
+	var out strings.Builder
+	table, ok := obj.Data.(*core.Map)
+	if !ok {
+		return ``, fmt.Errorf(ErrorText(ErrTomlRoot))
+	}
+	if err := writeToml(&out, table, ``); err != nil {
+		return ``, err
+	}
+	return out.String(), nil
+}
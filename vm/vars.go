@@ -12,7 +12,8 @@ import (
 
 // Fn is used for custom func types
 type Fn struct {
-	Func int32 // id of function
+	Func int32      // id of function
+	Env  []OptValue // the boxes of the variables captured by the closure
 }
 
 // CopyVar copies one object to another one
@@ -26,6 +27,7 @@ func CopyVar(rt *Runtime, ptr *interface{}, value interface{}) {
 			pfn = (*ptr).(*Fn)
 		}
 		pfn.Func = vItem.Func
+		pfn.Env = vItem.Env
 		*ptr = pfn
 	case *Struct:
 		var pstruct *Struct
@@ -136,6 +138,8 @@ func newValue(rt *Runtime, vtype int) interface{} {
 		return core.NewSet()
 	case core.TYPEOBJ:
 		return core.NewObj()
+	case core.TYPEIFACE:
+		return (*Struct)(nil)
 	default:
 		if vtype >= core.TYPESTRUCT {
 			return NewStruct(rt, &rt.Owner.Exec.Structs[(vtype-core.TYPESTRUCT)>>8])
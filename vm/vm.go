@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gentee/gentee/core"
 )
@@ -56,15 +58,36 @@ type VM struct {
 	CtxMutex    sync.RWMutex
 	ThreadMutex sync.RWMutex
 	LockMutex   sync.Mutex
+	NamedMutex  sync.Mutex
+	Locks       map[string]*namedLock // named locks
+	Blocked     map[int64]string      // threads waiting for named locks
+	Atomics     map[string]int64      // atomic counters
 	WaitGroup   sync.WaitGroup
 	Context     map[string]string
 	Count       int64 // count of active threads
 	WaitCount   int64
 	Stopped     bool
+	StopTime    int64 // the time of the suspending in nanoseconds
+	PausedTime  int64 // the summary duration of the suspending in nanoseconds
 	ChCount     chan int64
 	ChError     chan error
 	ChWait      chan int64
 	Playground  PlaygroundFS
+	Events      chan ThreadEvent // events of threads for RunAsync
+}
+
+// Timeout is the deadline of timeout block
+type Timeout struct {
+	Depth    int   // the depth of Calls of the block
+	Offset   int32 // the offset of the block
+	Deadline int64 // the deadline by the clock of VM
+	Expired  bool  // ErrTimeout has been raised for the deadline
+}
+
+// Defer is the deferred block which is called when the block of Calls is left
+type Defer struct {
+	Depth  int   // the index of the block in Calls
+	Offset int32 // the offset of the deferred block
 }
 
 type OptValue struct {
@@ -81,6 +104,9 @@ type Runtime struct {
 	Thread   Thread
 	ThreadID int64
 	Optional *[]OptValue
+	Timeouts []Timeout
+	Defers   []Defer
+	Pos      int64 // the latest saved offset of the bytecode
 	// These are stacks for different types
 	SInt   [STACKSIZE]int64       // int, char, bool
 	SFloat [STACKSIZE]float64     // float
@@ -107,17 +133,40 @@ type Call struct {
 	Try      int32 // shift for try
 	Recover  int32 // shift for recover
 	Retry    int32 // shift for retry
+	// for deferred blocks
+	Unwind error // the error which is passed to try after the deferred block
+}
+
+// clock returns the time of VM in nanoseconds without the duration of the suspending
+func (vm *VM) clock() int64 {
+	return time.Now().UnixNano() - atomic.LoadInt64(&vm.PausedTime)
+}
+
+func (vm *VM) suspend() {
+	if !vm.Stopped {
+		atomic.StoreInt64(&vm.StopTime, time.Now().UnixNano())
+		vm.Stopped = true
+	}
+}
+
+func (vm *VM) resume() {
+	if vm.Stopped {
+		atomic.AddInt64(&vm.PausedTime, time.Now().UnixNano()-atomic.LoadInt64(&vm.StopTime))
+		vm.Stopped = false
+	}
 }
 
 func (vm *VM) runConsts(offset int64) (interface{}, error) {
 	rt := &Runtime{
 		Owner: vm,
 	}
+	vm.ThreadMutex.Lock()
 	vm.Runtimes = append(vm.Runtimes, rt)
+	vm.ThreadMutex.Unlock()
 	return rt.Run(offset)
 }
 
-func Run(exec *core.Exec, settings Settings) (interface{}, error) {
+func newVM(exec *core.Exec, settings Settings) (*VM, error) {
 	if exec == nil {
 		return nil, fmt.Errorf(ErrorText(ErrNotRun))
 	}
@@ -135,6 +184,9 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 		Consts:   make(map[int32]Const),
 		Context:  make(map[string]string),
 		Runtimes: make([]*Runtime, 0, 32),
+		Locks:    make(map[string]*namedLock),
+		Blocked:  make(map[int64]string),
+		Atomics:  make(map[string]int64),
 		ChCount:  make(chan int64, 16),
 		ChError:  make(chan error, 16),
 		ChWait:   make(chan int64, 16),
@@ -148,6 +200,19 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 	if vm.Settings.Depth == 0 {
 		vm.Settings.Depth = DEPTH
 	}
+	return vm, nil
+}
+
+// Run executes the bytecode
+func Run(exec *core.Exec, settings Settings) (interface{}, error) {
+	vm, err := newVM(exec, settings)
+	if err != nil {
+		return nil, err
+	}
+	return vm.run()
+}
+
+func (vm *VM) run() (interface{}, error) {
 	var (
 		pStdin, pStdout, pStderr *os.File
 	)
@@ -181,7 +246,7 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 			vm.Consts[id] = Const{Type: core.TYPEINT, Value: int64(vm.Settings.Cycle)}
 			continue
 		case core.ConstScriptID:
-			vm.Consts[id] = Const{Type: core.TYPESTR, Value: exec.Path}
+			vm.Consts[id] = Const{Type: core.TYPESTR, Value: vm.Exec.Path}
 			continue
 		}
 		val, err := vm.runConsts(int64(vm.Exec.Funcs[id]))
@@ -211,7 +276,9 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 		}
 		vm.Consts[id] = Const{Type: constType, Value: val}
 	}
+	vm.ThreadMutex.Lock()
 	vm.Runtimes = vm.Runtimes[:0]
+	vm.ThreadMutex.Unlock()
 	rt := vm.newThread(ThWork)
 	go func() {
 		x := int64(1)
@@ -226,31 +293,33 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 			}
 		}
 	}()
-	if settings.SysChan != nil {
+	if vm.Settings.SysChan != nil {
 		go func() {
 		sysChan:
 			for {
-				x := <-settings.SysChan
+				x := <-vm.Settings.SysChan
 				switch x {
 				case sysClose:
 					break sysChan
 				case SysSuspend:
-					vm.Stopped = true
+					vm.suspend()
 				case SysResume:
-					vm.Stopped = false
+					vm.resume()
 				case SysTerminate:
 					rt.Owner.ChError <- fmt.Errorf(ErrorText(ErrTerminated))
-					vm.Stopped = false // if it has been suspended
+					vm.resume() // if it has been suspended
 				}
 			}
 		}()
 	}
 	result, errResult := rt.Run(0)
-	if settings.SysChan != nil {
-		settings.SysChan <- sysClose
+	if vm.Settings.SysChan != nil {
+		vm.Settings.SysChan <- sysClose
 	}
 	if errResult != nil {
 		vm.closeAll()
+	} else {
+		vm.stopTimers()
 	}
 	for vm.Count > 0 {
 		select {
@@ -262,10 +331,30 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 		default:
 		}
 	}
+	if errResult == nil {
+		// the errors of the kept threads which have not been got by ErrOf or Result
+		for _, thread := range vm.Runtimes[1:] {
+			if thread.Thread.Status == ThError && !thread.Thread.Caught {
+				errResult = thread.Thread.Err
+				break
+			}
+		}
+	}
 	if err, ok := errResult.(*RuntimeError); ok && err.Message == ErrorText(ErrExit) {
 		result = err.ID
 		errResult = nil
 	}
+	vm.ThreadMutex.Lock()
+	if errResult != nil {
+		rt.Thread.Status = ThError
+	} else {
+		rt.Thread.Status = ThFinished
+	}
+	vm.ThreadMutex.Unlock()
+	vm.event(rt, errResult)
+	if vm.Events != nil {
+		close(vm.Events)
+	}
 	vm.ChCount <- 0
 	close(vm.Runtimes[0].Thread.Chan)
 	close(vm.ChCount)
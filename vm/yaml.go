@@ -0,0 +1,267 @@
+// Copyright 2020 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+	"gopkg.in/yaml.v3"
+)
+
+var yamlKey = regexp.MustCompile(`^[\w\-./]+$`)
+
+// yamlMaxAliased is the maximum count of nodes which are copied by aliases
+const yamlMaxAliased = 1000000
+
+func yamlError(line int, format string, args ...interface{}) error {
+	return fmt.Errorf(ErrorText(ErrParseLine), `yaml`, line, fmt.Sprintf(format, args...))
+}
+
+// yamlDecoder converts the nodes of YAML document to obj
+type yamlDecoder struct {
+	aliases map[*yaml.Node]bool // the anchors which are being expanded
+	aliased int                 // the count of nodes copied by aliases
+}
+
+func (d *yamlDecoder) scalar(node *yaml.Node) (*core.Obj, error) {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, yamlError(node.Line, `%v`, err)
+	}
+	ret := core.NewObj()
+	switch v := value.(type) {
+	case int:
+		ret.Data = int64(v)
+	case uint64:
+		ret.Data = float64(v)
+	case nil, bool, int64, float64, string:
+		ret.Data = v
+	default:
+		ret.Data = node.Value
+	}
+	return ret, nil
+}
+
+// merge appends the fields of the mapping of << key which are not defined in the map
+func (d *yamlDecoder) merge(data *core.Map, node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			if err := d.merge(data, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	obj, err := d.node(node)
+	if err != nil {
+		return err
+	}
+	src, ok := obj.Data.(*core.Map)
+	if !ok {
+		return yamlError(node.Line, `map merge requires map or sequence of maps as the value`)
+	}
+	for _, key := range src.Keys {
+		if _, ok := data.Data[key]; !ok {
+			data.Keys = append(data.Keys, key)
+			data.Data[key] = src.Data[key]
+		}
+	}
+	return nil
+}
+
+func (d *yamlDecoder) node(node *yaml.Node) (*core.Obj, error) {
+	if len(d.aliases) > 0 {
+		if d.aliased++; d.aliased > yamlMaxAliased {
+			return nil, yamlError(node.Line, `document contains excessive aliasing`)
+		}
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return core.NewObj(), nil
+		}
+		return d.node(node.Content[0])
+	case yaml.AliasNode:
+		if d.aliases[node.Alias] {
+			return nil, yamlError(node.Line, `anchor %s references itself`, node.Value)
+		}
+		d.aliases[node.Alias] = true
+		defer delete(d.aliases, node.Alias)
+		return d.node(node.Alias)
+	case yaml.SequenceNode:
+		data := core.NewArray()
+		for _, item := range node.Content {
+			iobj, err := d.node(item)
+			if err != nil {
+				return nil, err
+			}
+			data.Data = append(data.Data, iobj)
+		}
+		ret := core.NewObj()
+		ret.Data = data
+		return ret, nil
+	case yaml.MappingNode:
+		data := core.NewMap()
+		var merges []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			if keyNode.Kind == yaml.ScalarNode && keyNode.ShortTag() == `!!merge` {
+				merges = append(merges, node.Content[i+1])
+				continue
+			}
+			if keyNode.Kind != yaml.ScalarNode {
+				return nil, yamlError(keyNode.Line, `key must be a scalar`)
+			}
+			key := keyNode.Value
+			if _, ok := data.Data[key]; ok {
+				return nil, yamlError(keyNode.Line, `duplicate key %s`, key)
+			}
+			iobj, err := d.node(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			data.Keys = append(data.Keys, key)
+			data.Data[key] = iobj
+		}
+		for _, item := range merges {
+			if err := d.merge(data, item); err != nil {
+				return nil, err
+			}
+		}
+		ret := core.NewObj()
+		ret.Data = data
+		return ret, nil
+	}
+	return d.scalar(node)
+}
+
+// YamlToObj converts YAML to object. It supports YAML 1.2 documents including anchors, aliases
+// and << merge keys. Only the first document of the stream is converted.
+func YamlToObj(input string) (*core.Obj, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(input), &root); err != nil {
+		return nil, err
+	}
+	d := &yamlDecoder{aliases: make(map[*yaml.Node]bool)}
+	return d.node(&root)
+}
+
+// isPlainYaml returns true if the plain scalar is decoded as the same string
+func isPlainYaml(value string) bool {
+	var node yaml.Node
+	if yaml.Unmarshal([]byte(value), &node) != nil || len(node.Content) != 1 {
+		return false
+	}
+	scalar := node.Content[0]
+	return scalar.Kind == yaml.ScalarNode && scalar.Style == 0 && scalar.ShortTag() == `!!str` &&
+		scalar.Value == value
+}
+
+// yamlString returns the string as plain or double-quoted scalar
+func yamlString(value string) string {
+	if !isPlainYaml(value) || !yamlKey.MatchString(value) &&
+		(strings.ContainsAny(value, "\n\t\"'#:[]{},&*!|>%@`") || value != strings.TrimSpace(value) ||
+			strings.HasPrefix(value, `-`) || strings.HasPrefix(value, `?`)) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func yamlScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return `null`
+	case string:
+		return yamlString(v)
+	case *core.Array:
+		return `[]`
+	case *core.Map:
+		return `{}`
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return `.inf`
+		case math.IsInf(v, -1):
+			return `-.inf`
+		case math.IsNaN(v):
+			return `.nan`
+		}
+		ret := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(ret, `.e`) {
+			ret += `.0`
+		}
+		return ret
+	}
+	return fmt.Sprint(value)
+}
+
+// isBlockObj returns true if the value is not the empty array or map
+func isBlockObj(value interface{}) bool {
+	switch v := value.(type) {
+	case *core.Array:
+		return len(v.Data) > 0
+	case *core.Map:
+		return len(v.Keys) > 0
+	case *core.Obj:
+		return isBlockObj(v.Data)
+	}
+	return false
+}
+
+func writeYaml(out *strings.Builder, value interface{}, indent string) {
+	switch v := value.(type) {
+	case *core.Obj:
+		writeYaml(out, v.Data, indent)
+	case *core.Array:
+		if len(v.Data) == 0 {
+			out.WriteString("[]\n")
+			return
+		}
+		for i, item := range v.Data {
+			if i > 0 {
+				out.WriteString(indent)
+			}
+			out.WriteString(`-`)
+			if isBlockObj(item) {
+				out.WriteString(` `)
+				writeYaml(out, item, indent+`  `)
+			} else {
+				out.WriteString(` ` + yamlScalar(item.(*core.Obj).Data) + "\n")
+			}
+		}
+	case *core.Map:
+		if len(v.Keys) == 0 {
+			out.WriteString("{}\n")
+			return
+		}
+		for i, key := range v.Keys {
+			if i > 0 {
+				out.WriteString(indent)
+			}
+			out.WriteString(yamlString(key) + `:`)
+			item := v.Data[key]
+			if !isBlockObj(item) {
+				out.WriteString(` ` + yamlScalar(item.(*core.Obj).Data) + "\n")
+				continue
+			}
+			out.WriteString("\n" + indent + `  `)
+			writeYaml(out, item, indent+`  `)
+		}
+	default:
+		out.WriteString(yamlScalar(v) + "\n")
+	}
+}
+
+// Yaml converts object to YAML
+func Yaml(obj *core.Obj) string {
+	var out strings.Builder
+	writeYaml(&out, obj, ``)
+	return out.String()
+}